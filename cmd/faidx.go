@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/benekenobi/colordna/internal/colorer"
+	"github.com/benekenobi/colordna/internal/parser"
+	"github.com/spf13/cobra"
+)
+
+// faidxCmd represents the faidx command
+var faidxCmd = &cobra.Command{
+	Use:   "faidx <file.fa>...",
+	Short: "Build a FASTA index (.fai)",
+	Long: `Build a samtools-compatible FASTA index (<file>.fai) for each file.
+With an index, 'colordna genome.fa chr3:100-300' reads and colors only that region
+instead of the whole file. Without one, the file is indexed in memory on every run.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runFaidx,
+}
+
+func runFaidx(cmd *cobra.Command, args []string) error {
+	for _, filename := range args {
+		entries, err := buildFAIFile(filename)
+		if err != nil {
+			return err
+		}
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Indexed %d sequence(s) in %s\n", len(entries), filename)
+		}
+	}
+	return nil
+}
+
+// buildFAIFile indexes a FASTA file and writes the index next to it
+func buildFAIFile(filename string) ([]parser.FAIEntry, error) {
+	entries, err := indexFASTA(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := os.Create(filename + ".fai")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create index: %w", err)
+	}
+	if err := parser.WriteFAI(index, entries); err != nil {
+		index.Close()
+		return nil, fmt.Errorf("failed to write index: %w", err)
+	}
+	return entries, index.Close()
+}
+
+// fastaIndex is the index of a FASTA file, loaded when it is first needed
+type fastaIndex struct {
+	filename string
+	entries  map[string]parser.FAIEntry
+	err      error
+	loaded   bool
+}
+
+// load reads <file>.fai, or indexes the FASTA file in memory when there is no index file
+func (f *fastaIndex) load() (map[string]parser.FAIEntry, error) {
+	if f.loaded {
+		return f.entries, f.err
+	}
+	f.loaded = true
+
+	var entries []parser.FAIEntry
+	index, err := os.Open(f.filename + ".fai")
+	if errors.Is(err, fs.ErrNotExist) {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "No index %s.fai, indexing in memory (run 'colordna faidx' to save it)\n", f.filename)
+		}
+		entries, f.err = indexFASTA(f.filename)
+	} else if err != nil {
+		f.err = fmt.Errorf("failed to open index: %w", err)
+	} else {
+		defer index.Close()
+		if entries, err = parser.ReadFAI(index); err != nil {
+			f.err = fmt.Errorf("failed to read index %s.fai: %w", f.filename, err)
+		}
+	}
+
+	f.entries = make(map[string]parser.FAIEntry, len(entries))
+	for _, entry := range entries {
+		f.entries[entry.Name] = entry
+	}
+	return f.entries, f.err
+}
+
+// has reports whether the FASTA file contains a sequence with the given name. It also
+// reports true when the index cannot be loaded, so that the error is reported when the
+// region is extracted instead of the name being taken for a missing file.
+func (f *fastaIndex) has(name string) bool {
+	entries, err := f.load()
+	if err != nil {
+		return true
+	}
+	_, exists := entries[name]
+	return exists
+}
+
+// indexFASTA indexes a FASTA file without writing the index
+func indexFASTA(filename string) ([]parser.FAIEntry, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	entries, err := parser.BuildFAI(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to index %s: %w", filename, err)
+	}
+	return entries, nil
+}
+
+// isRegionArg reports whether a command-line argument following a FASTA file is a region
+// of it: not an existing file, and either "name:start[-end]" or the name of a sequence
+func isRegionArg(arg string, index *fastaIndex) bool {
+	if _, err := os.Stat(arg); err == nil {
+		return false
+	}
+	if strings.Contains(arg, ":") {
+		if _, _, _, ok := parser.ParseRegion(arg); ok {
+			return true
+		}
+	}
+	return index.has(arg)
+}
+
+// processRegions extracts regions of an indexed FASTA file and colors them as FASTA
+// records named after the region. Regions that cannot be read are reported and skipped,
+// and counted in the returned error.
+func processRegions(index *fastaIndex, regions []string, colorizer *colorer.Colorer) error {
+	entries, err := index.load()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(index.filename)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	state := &recordState{}
+	failed := 0
+	for _, region := range regions {
+		if err := processRegion(file, entries, region, colorizer, state); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", index.filename, err)
+			failed++
+		}
+	}
+	flushRecord(state, colorizer)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d region(s) could not be extracted", failed, len(regions))
+	}
+	return nil
+}
+
+// processRegion extracts one region and colors it as a FASTA record
+func processRegion(file *os.File, entries map[string]parser.FAIEntry, region string, colorizer *colorer.Colorer, state *recordState) error {
+	name, start, end, _ := parser.ParseRegion(region)
+	entry, exists := entries[name]
+	if !exists {
+		// Names may contain colons, so fall back to the whole argument
+		if entry, exists = entries[region]; !exists {
+			return fmt.Errorf("sequence '%s' not found", name)
+		}
+		start, end = 1, 0
+	}
+	if end == 0 || end > entry.Length {
+		end = entry.Length
+	}
+	if start > end {
+		return fmt.Errorf("region '%s' is outside sequence '%s' (length %d)", region, entry.Name, entry.Length)
+	}
+
+	sequence, err := entry.Fetch(file, start, end)
+	if err != nil {
+		return fmt.Errorf("failed to read region '%s': %w", region, err)
+	}
+
+	header := ">" + entry.Name
+	if start > 1 || end < entry.Length {
+		header = fmt.Sprintf(">%s:%d-%d", entry.Name, start, end)
+	}
+	processLine(header, parser.FormatFASTA, colorizer, state)
+	state.basePosition = start - 1
+	for len(sequence) > 0 {
+		width := min(entry.LineBases, len(sequence))
+		processLine(sequence[:width], parser.FormatFASTA, colorizer, state)
+		sequence = sequence[width:]
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(faidxCmd)
+}
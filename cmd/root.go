@@ -2,10 +2,13 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/benekenobi/colordna/internal/colorer"
@@ -18,18 +21,114 @@ var (
 	colorScheme string
 	configFile  string
 	verbose     bool
+	adapters    []string
+	enzymes     []string
+	qualityMask int
+
+	qualityEncodingName string
+	qualityEncoding     parser.QualityEncoding
+	qualityDisplayName  string
+	minDepth            int
+	minGQ               int
+	variantTypes        bool
+	infoKeys            []string
+	infoAligned         bool
+	samFlagModeName     string
+	maxArrayValues      int
+	headerSummary       bool
+	heatRange           string
+	showFeatures        bool
+	conservation        bool
+	showTracks          bool
+	trackThreshold      float64
+	maxSegment          int
+	gfaSummary          bool
+	wrapWidth           int
+	showRuler           bool
+	showCoordinates     bool
+	groupSize           int
+	groupGap            int
+	reverseComplement   bool
+	transcribe          bool
+	backTranscribe      bool
+	homopolymerMin      int
+	tandemMin           int
+	repeatStyleName     string
+	repeatLengths       bool
+	colorModeName       string
+	windowSize          int
+)
+
+const (
+	// qualityDetectionLines is the number of lines inspected to detect the quality encoding
+	qualityDetectionLines = 400
+	// heatRangeLines is the number of bedGraph lines inspected to find the value range
+	heatRangeLines = 1000
+	// alignmentDetectionLines is the number of gapped FASTA lines inspected to choose
+	// between nucleotide and amino acid colors
+	alignmentDetectionLines = 1000
+	// rulerBlockLines is the number of sequence lines in each block under a --ruler scale
+	rulerBlockLines = 10
+)
+
+// recordState carries record-level context between lines of the same input
+type recordState struct {
+	fastqLine int    // Position within the current 4-line FASTQ record
+	sequence  string // FASTQ sequence line awaiting its quality line
+	separator string // FASTQ '+' line awaiting its quality line
+
+	wrapBuffer   string // FASTA bases awaiting a full --wrap line
+	basePosition int    // Bases of the current record already printed
+	rulerDue     bool   // A --ruler scale is due before the next sequence line
+	rulerLines   int    // Sequence lines printed since the last --ruler scale
+
+	recordBuffer    []byte // FASTA record held until it is complete
+	recordLineWidth int    // Line width of the held FASTA record
+
+	vcfHeader *parser.VCFHeader // INFO/FORMAT definitions read from the VCF header
+	samHeader *parser.SAMHeader // @SQ/@PG records awaiting the header summary
+	inFASTA   bool              // Inside the ##FASTA section of a GFF3 file
+
+	flatSection     flatFileSection      // Current section of a GenBank/EMBL record
+	featureKey      string               // Key of the feature whose location is being read
+	featureLocation string               // Location of the current feature, possibly spanning lines
+	features        []parser.FeatureSpan // Spans of the features read so far in the record
+
+	alignment        []parser.AlignmentRow // Rows of the current alignment block
+	phylipTaxa       int                   // Number of taxa declared in the PHYLIP header
+	phylipRows       int                   // PHYLIP rows read, which are named until phylipTaxa is reached
+	alignedHeaders   []string              // Aligned FASTA headers held back for --conservation
+	alignedRecords   [][]string            // Aligned FASTA sequence lines held back for --conservation
+	mafReference     string                // Text of the first "s" line of the current MAF block
+	conservationLine string                // Clustal conservation line of the current block
+
+	gfaSegments []int // Segment lengths collected for --gfa-summary
+	gfaLinks    int   // Links and edges counted for --gfa-summary
+	gfaPaths    int   // Paths and walks counted for --gfa-summary
+}
+
+// flatFileSection identifies the part of a GenBank/EMBL record being read
+type flatFileSection int
+
+const (
+	sectionHeader flatFileSection = iota
+	sectionFeatures
+	sectionSequence
 )
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
-	Use:   "colordna [file...]",
+	Use:   "colordna [file [region...]...]",
 	Short: "Color DNA/RNA sequences and quality scores in terminal output",
 	Long: `colordna is a command-line tool that colorizes DNA/RNA sequences and quality scores
 for better visualization in the terminal. It supports multiple file formats including
-FASTA, FASTQ, SAM, and VCF with automatic format detection.
+FASTA, FASTQ, SAM, VCF, GFF3/GTF, BED, GenBank, EMBL, multiple sequence alignments
+PAF/MAF whole-genome alignments, samtools mpileup and GFA assembly graphs with
+automatic format detection.
 
 Features:
-- Automatic file format detection (FASTA, FASTQ, SAM, VCF)
+- Automatic file format detection (FASTA, FASTQ, SAM, VCF, GFF3/GTF, BED, bedGraph,
+  GenBank, EMBL, Clustal, Stockholm, PHYLIP, aligned FASTA, PAF, MAF, mpileup, GFA)
 - Multiple color schemes with customizable colors
 - Support for both sequence and quality score coloring
 - Pipe support for streaming data
@@ -39,7 +138,8 @@ Examples:
   colordna sequences.fasta
   colordna --scheme bright sequences.fastq
   cat file.sam | colordna
-  colordna file1.fasta file2.fastq`,
+  colordna file1.fasta file2.fastq
+  colordna genome.fa chr3:100-300`,
 	RunE:               runColordna,
 	DisableFlagParsing: false,
 	DisableAutoGenTag:  true,
@@ -76,6 +176,91 @@ func runColordna(cmd *cobra.Command, args []string) error {
 
 	colorizer := colorer.New(scheme)
 
+	qualityEncoding, err = parser.ParseQualityEncoding(qualityEncodingName)
+	if err != nil {
+		return err
+	}
+
+	display, err := colorer.ParseQualityDisplay(qualityDisplayName)
+	if err != nil {
+		return err
+	}
+	colorizer.SetQualityDisplay(display)
+	colorizer.SetGenotypeThresholds(minDepth, minGQ)
+	colorizer.SetVariantTypes(variantTypes)
+	colorizer.SetInfoKeys(infoKeys)
+	colorizer.SetInfoAligned(infoAligned)
+
+	samFlagMode, err := colorer.ParseSAMFlagMode(samFlagModeName)
+	if err != nil {
+		return err
+	}
+	colorizer.SetSAMFlagMode(samFlagMode)
+	colorizer.SetMaxArrayValues(maxArrayValues)
+	colorizer.SetConservation(conservation)
+	colorizer.SetTrackThreshold(trackThreshold)
+	colorizer.SetMaxSegmentLength(maxSegment)
+	colorizer.SetTruecolor(supportsTruecolor())
+	if wrapWidth < 0 {
+		return fmt.Errorf("--wrap must not be negative")
+	}
+	if groupSize < 0 || groupGap < 0 {
+		return fmt.Errorf("--group and --group-gap must not be negative")
+	}
+	if !cmd.Flags().Changed("group-gap") {
+		// Default to a wider gap every five groups, 50 bases for groups of 10
+		groupGap = 5 * groupSize
+	} else if groupSize > 0 && groupGap%groupSize != 0 {
+		return fmt.Errorf("--group-gap %d is not a multiple of --group %d", groupGap, groupSize)
+	}
+	if err := checkQualityLayout(display, wrapWidth, groupSize); err != nil {
+		return err
+	}
+	colorizer.SetGrouping(groupSize, groupGap)
+
+	if transcribe && backTranscribe {
+		return fmt.Errorf("--transcribe and --back-transcribe cannot be used together")
+	}
+	transcription := colorer.TranscriptionNone
+	if transcribe {
+		transcription = colorer.TranscriptionRNA
+	} else if backTranscribe {
+		transcription = colorer.TranscriptionDNA
+	}
+	colorizer.SetTransform(reverseComplement, transcription)
+
+	repeatStyle, err := colorer.ParseRepeatStyle(repeatStyleName)
+	if err != nil {
+		return err
+	}
+	colorizer.SetRepeats(homopolymerMin, tandemMin, repeatStyle, repeatLengths)
+
+	colorMode, err := colorer.ParseColorMode(colorModeName)
+	if err != nil {
+		return err
+	}
+	if windowSize < 1 {
+		return fmt.Errorf("--window must be at least 1")
+	}
+	colorizer.SetColorMode(colorMode, windowSize)
+
+	motifs, err := buildMotifs(cfg)
+	if err != nil {
+		return err
+	}
+	if len(motifs) > 0 {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Highlighting %d motif(s)\n", len(motifs))
+		}
+		colorizer.SetMotifs(motifs)
+	}
+	if qualityMask > 0 {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Masking bases with quality below Q%d\n", qualityMask)
+		}
+		colorizer.SetQualityMask(qualityMask)
+	}
+
 	// If no files specified, read from stdin
 	if len(args) == 0 {
 		if verbose {
@@ -88,11 +273,30 @@ func runColordna(cmd *cobra.Command, args []string) error {
 	if verbose {
 		fmt.Fprintf(os.Stderr, "Processing %d file(s)\n", len(args))
 	}
-	for i, filename := range args {
+	regionsFailed := false
+	for i := 0; i < len(args); i++ {
+		filename := args[i]
 		if verbose {
 			fmt.Fprintf(os.Stderr, "[%d/%d] Processing file: %s\n", i+1, len(args), filename)
 		}
-		if err := processFile(filename, colorizer); err != nil {
+
+		// Regions after a FASTA file are read through its index
+		var regions []string
+		index := &fastaIndex{filename: filename}
+		if parser.DetectFormatFromFilename(filename) == parser.FormatFASTA {
+			for i+1 < len(args) && isRegionArg(args[i+1], index) {
+				regions = append(regions, args[i+1])
+				i++
+			}
+		}
+
+		if len(regions) > 0 {
+			if err := processRegions(index, regions, colorizer); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s: %v\n", filename, err)
+				regionsFailed = true
+				continue
+			}
+		} else if err := processFile(filename, colorizer); err != nil {
 			if verbose {
 				fmt.Fprintf(os.Stderr, "Error processing %s: %v\n", filename, err)
 			}
@@ -103,9 +307,39 @@ func runColordna(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if regionsFailed {
+		// The errors have been reported, only the exit status is left
+		cmd.SilenceErrors, cmd.SilenceUsage = true, true
+		return errors.New("regions could not be extracted")
+	}
 	return nil
 }
 
+// buildMotifs resolves the --adapters and --enzymes names against the configured libraries
+func buildMotifs(cfg *config.Config) ([]colorer.Motif, error) {
+	var motifs []colorer.Motif
+
+	for _, name := range adapters {
+		sequences, exists := cfg.Adapter(name)
+		if !exists {
+			return nil, fmt.Errorf("adapter set '%s' not found", name)
+		}
+		for _, sequence := range sequences {
+			motifs = append(motifs, colorer.NewAdapterMotif(name, sequence))
+		}
+	}
+
+	for _, name := range enzymes {
+		site, exists := cfg.Enzyme(name)
+		if !exists {
+			return nil, fmt.Errorf("restriction enzyme '%s' not found", name)
+		}
+		motifs = append(motifs, colorer.NewEnzymeMotif(name, site))
+	}
+
+	return motifs, nil
+}
+
 func processFile(filename string, colorizer *colorer.Colorer) error {
 	file, err := os.Open(filename)
 	if err != nil {
@@ -152,12 +386,74 @@ func processReader(reader io.Reader, colorizer *colorer.Colorer, filename string
 		fmt.Fprintf(os.Stderr, "Format detected from content: %s\n", formatToString(format))
 	}
 
+	// Detect the quality encoding from the first records; SAM and the other formats are
+	// always Phred+33, whatever --quality-encoding says
+	encoding := parser.EncodingSanger
+	if format == parser.FormatFASTQ {
+		encoding = qualityEncoding
+	}
+	if format == parser.FormatFASTQ && encoding == parser.EncodingUnknown {
+		for len(lines) < qualityDetectionLines && scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("error reading input: %w", err)
+		}
+		encoding = parser.DetectQualityEncoding(parser.FASTQQualityLines(lines))
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Quality encoding detected: %s\n", encoding)
+		}
+	} else if verbose && format == parser.FormatFASTQ {
+		fmt.Fprintf(os.Stderr, "Quality encoding: %s\n", encoding)
+	}
+	colorizer.SetQualityEncoding(encoding)
+
+	// Choose one palette for a streamed gapped FASTA alignment from its first lines;
+	// with --conservation or --tracks the whole alignment is read before coloring
+	alphabet := colorer.AlphabetAuto
+	if format == parser.FormatAlignedFASTA && !conservation && !showTracks {
+		for len(lines) < alignmentDetectionLines && scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("error reading input: %w", err)
+		}
+		alphabet = colorer.DetectAlignmentAlphabet(lines)
+	}
+	colorizer.SetAlignmentAlphabet(alphabet)
+
+	// Find the bedGraph value range for the heat gradient
+	if format == parser.FormatBedGraph {
+		for len(lines) < heatRangeLines && scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("error reading input: %w", err)
+		}
+		min, max, err := bedGraphRange(lines)
+		if err != nil {
+			return err
+		}
+		if verbose {
+			fmt.Fprintf(os.Stderr, "bedGraph heat range: %g to %g\n", min, max)
+		}
+		colorizer.SetHeatRange(min, max)
+	}
+
 	lineCount := 0
 	sequenceCount := 0
+	state := &recordState{}
+	if format == parser.FormatVCF {
+		state.vcfHeader = parser.NewVCFHeader()
+		colorizer.SetVCFHeader(state.vcfHeader)
+	}
+	if format == parser.FormatSAM && headerSummary {
+		state.samHeader = &parser.SAMHeader{}
+	}
 
 	// Process the buffered lines first
 	for _, line := range lines {
-		processLine(line, format, colorizer)
+		processLine(line, format, colorizer, state)
 		lineCount++
 		if isSequenceCountableLine(line, format) {
 			sequenceCount++
@@ -166,12 +462,13 @@ func processReader(reader io.Reader, colorizer *colorer.Colorer, filename string
 
 	// Continue processing the rest of the input
 	for scanner.Scan() {
-		processLine(scanner.Text(), format, colorizer)
+		processLine(scanner.Text(), format, colorizer, state)
 		lineCount++
 		if isSequenceCountableLine(scanner.Text(), format) {
 			sequenceCount++
 		}
 	}
+	flushRecord(state, colorizer)
 
 	if verbose {
 		fmt.Fprintf(os.Stderr, "Processed %d lines, %d sequences\n", lineCount, sequenceCount)
@@ -180,51 +477,607 @@ func processReader(reader io.Reader, colorizer *colorer.Colorer, filename string
 	return scanner.Err()
 }
 
-func processLine(line string, format parser.Format, colorizer *colorer.Colorer) {
+func processLine(line string, format parser.Format, colorizer *colorer.Colorer, state *recordState) {
 	switch format {
 	case parser.FormatFASTA:
-		if strings.HasPrefix(line, ">") {
-			// Header line - print as is
-			fmt.Println(line)
-		} else {
-			// Sequence line - colorize
-			fmt.Println(colorizer.ColorizeSequence(line))
-		}
+		processFASTALine(line, colorizer, state)
 	case parser.FormatFASTQ:
-		if strings.HasPrefix(line, "@") || strings.HasPrefix(line, "+") {
-			// Header lines - print as is
-			fmt.Println(line)
-		} else if parser.IsSequenceLine(line) {
-			// Sequence line - colorize
-			fmt.Println(colorizer.ColorizeSequence(line))
-		} else if parser.IsQualityLine(line) {
-			// Quality line - colorize
-			fmt.Println(colorizer.ColorizeQuality(line))
-		} else {
-			fmt.Println(line)
-		}
+		processFASTQLine(line, colorizer, state)
 	case parser.FormatSAM:
 		if strings.HasPrefix(line, "@") {
-			// Header line - print as is
-			fmt.Println(line)
+			// Header line - collect for the summary or colorize
+			if state.samHeader != nil && state.samHeader.AddLine(line) {
+				return
+			}
+			fmt.Println(colorizer.ColorizeSAMHeader(line))
 		} else {
 			// Data line - colorize sequence column
+			printSAMHeaderSummary(state, colorizer)
 			fmt.Println(colorizer.ColorizeSAM(line))
 		}
 	case parser.FormatVCF:
 		if strings.HasPrefix(line, "#") {
-			// Header line - print as is
+			// Header line - record INFO/FORMAT definitions and print as is
+			if state.vcfHeader != nil {
+				state.vcfHeader.ParseLine(line)
+			}
 			fmt.Println(line)
 		} else {
 			// Data line - colorize relevant columns
 			fmt.Println(colorizer.ColorizeVCF(line))
 		}
+	case parser.FormatBED, parser.FormatBedGraph:
+		if strings.HasPrefix(line, "track") || strings.HasPrefix(line, "browser") {
+			// Track definition - highlight the keyword
+			fmt.Println(colorizer.ColorizeTrackLine(line))
+		} else if strings.HasPrefix(line, "#") {
+			// Comment - print as is
+			fmt.Println(line)
+		} else if format == parser.FormatBedGraph {
+			fmt.Println(colorizer.ColorizeBedGraph(line))
+		} else {
+			fmt.Println(colorizer.ColorizeBED(line))
+		}
+	case parser.FormatGenBank, parser.FormatEMBL:
+		processFlatFileLine(line, format, colorizer, state)
+	case parser.FormatClustal, parser.FormatStockholm, parser.FormatPHYLIP:
+		processAlignmentLine(line, format, colorizer, state)
+	case parser.FormatAlignedFASTA:
+		processAlignedFASTALine(line, colorizer, state)
+	case parser.FormatPAF:
+		fmt.Println(colorizer.ColorizePAF(line))
+	case parser.FormatPileup:
+		fmt.Println(colorizer.ColorizePileup(line))
+	case parser.FormatGFA:
+		if gfaSummary {
+			collectGFAStats(line, state)
+		} else {
+			fmt.Println(colorizer.ColorizeGFA(line))
+		}
+	case parser.FormatMAF:
+		// A new block starts with an "a" line, its first "s" line is the reference
+		if strings.HasPrefix(line, "a") {
+			state.mafReference = ""
+		} else if text, _, ok := parser.MAFSequenceText(line); ok && state.mafReference == "" {
+			state.mafReference = text
+		}
+		fmt.Println(colorizer.ColorizeMAFLine(line, state.mafReference))
+	case parser.FormatGFF:
+		if state.inFASTA {
+			// Embedded sequences - colorize as FASTA
+			processLine(line, parser.FormatFASTA, colorizer, state)
+		} else if strings.HasPrefix(line, "#") {
+			// Directive or comment - print as is
+			state.inFASTA = line == "##FASTA"
+			fmt.Println(line)
+		} else {
+			// Feature line - colorize columns and attributes
+			fmt.Println(colorizer.ColorizeGFF(line))
+		}
 	default:
 		// Unknown format - just print as is
 		fmt.Println(line)
 	}
 }
 
+// processFASTQLine colorizes a FASTQ line based on its position in the 4-line record,
+// holding back the sequence when it has to be masked by the following quality line
+func processFASTQLine(line string, colorizer *colorer.Colorer, state *recordState) {
+	switch state.fastqLine {
+	case 0:
+		// Header line - print as is
+		if strings.HasPrefix(line, "@") {
+			line = colorizer.AnnotateHeader(line)
+			state.fastqLine = 1
+		}
+		fmt.Println(line)
+	case 1:
+		// Sequence line
+		state.fastqLine = 2
+		line = colorizer.TransformSequence(line)
+		if qualityMask > 0 || layoutEnabled() {
+			state.sequence = line
+			return
+		}
+		fmt.Println(colorizer.ColorizeSequence(line))
+	case 2:
+		// Separator line - print as is
+		state.fastqLine = 3
+		if qualityMask > 0 || layoutEnabled() {
+			state.separator = line
+			return
+		}
+		fmt.Println(line)
+	default:
+		// Quality line
+		state.fastqLine = 0
+		line = colorizer.TransformQuality(line)
+		if layoutEnabled() {
+			printFASTQLayout(state.sequence, state.separator, line, colorizer)
+			state.sequence, state.separator = "", ""
+			return
+		}
+		if qualityMask > 0 {
+			fmt.Println(colorizer.ColorizeMaskedSequence(state.sequence, line))
+			fmt.Println(state.separator)
+			state.sequence, state.separator = "", ""
+		}
+		fmt.Println(colorizer.ColorizeQuality(line))
+	}
+}
+
+// processFASTALine applies --revcomp, --transcribe and --back-transcribe to a FASTA
+// line and prints it with the --wrap layout. Records are held until they are complete
+// when they are reverse complemented or their colors depend on bases in other lines.
+func processFASTALine(line string, colorizer *colorer.Colorer, state *recordState) {
+	if strings.HasPrefix(line, ">") {
+		flushFASTARecord(colorizer, state)
+		fmt.Println(colorizer.AnnotateHeader(line))
+		state.basePosition = 0
+		state.rulerDue, state.rulerLines = showRuler, 0
+		return
+	}
+	if reverseComplement || colorizer.ScansWholeSequence() {
+		if state.recordLineWidth == 0 {
+			state.recordLineWidth = len(line)
+		}
+		state.recordBuffer = append(state.recordBuffer, line...)
+		return
+	}
+
+	line = colorizer.TransformSequence(line)
+	if wrapWidth == 0 {
+		printSequence(line, "", len(line), colorizer, state)
+		return
+	}
+	state.wrapBuffer += line
+	for len(state.wrapBuffer) >= wrapWidth {
+		printSequence(state.wrapBuffer[:wrapWidth], "", wrapWidth, colorizer, state)
+		state.wrapBuffer = state.wrapBuffer[wrapWidth:]
+	}
+}
+
+// flushFASTARecord prints the held FASTA record, keeping the line width of the input
+// unless --wrap is given, and the last, partial line of a reflowed record
+func flushFASTARecord(colorizer *colorer.Colorer, state *recordState) {
+	if state.wrapBuffer != "" {
+		printSequence(state.wrapBuffer, "", wrapWidth, colorizer, state)
+		state.wrapBuffer = ""
+	}
+	if len(state.recordBuffer) == 0 {
+		return
+	}
+
+	width := wrapWidth
+	if width == 0 {
+		width = state.recordLineWidth
+	}
+	sequence := colorizer.TransformSequence(string(state.recordBuffer))
+	printSequence(sequence, "", width, colorizer, state)
+	state.recordBuffer, state.recordLineWidth = nil, 0
+}
+
+// supportsTruecolor reports whether the terminal advertises 24-bit colors in COLORTERM
+func supportsTruecolor() bool {
+	colorTerm := os.Getenv("COLORTERM")
+	return colorTerm == "truecolor" || colorTerm == "24bit"
+}
+
+// checkQualityLayout rejects --quality-display numbers with --wrap or --group: scores are
+// three columns wide, so the quality rows could not line up under their bases
+func checkQualityLayout(display colorer.QualityDisplay, wrap, group int) error {
+	if display == colorer.QualityNumbers && (wrap > 0 || group > 0) {
+		return fmt.Errorf("--quality-display numbers cannot be used with --wrap or --group")
+	}
+	return nil
+}
+
+// layoutEnabled reports whether FASTA/FASTQ sequences are laid out with --wrap,
+// --ruler, --coordinates or --group
+func layoutEnabled() bool {
+	return wrapWidth > 0 || showRuler || showCoordinates || groupSize > 0
+}
+
+// printSequence colorizes a whole sequence and prints it in lines of width bases, or as
+// one line when width is 0, each with its part of the quality string beneath it when
+// quality is given. Lines are rendered as they are printed.
+func printSequence(sequence, quality string, width int, colorizer *colorer.Colorer, state *recordState) {
+	if width <= 0 {
+		width = max(len(sequence), 1)
+	}
+	lines := colorizer.ScanSequenceLines(sequence, quality)
+	for from := 0; from == 0 || from < len(sequence); from += width {
+		to := min(from+width, len(sequence))
+		printSequenceLine(lines.Line(from, to), to-from, quality[min(from, len(quality)):min(to, len(quality))], colorizer, state)
+	}
+}
+
+// printSequenceLine prints one colorized, laid-out sequence line of the given length,
+// with its quality line beneath it when quality is given
+func printSequenceLine(line string, length int, quality string, colorizer *colorer.Colorer, state *recordState) {
+	width := length
+	if wrapWidth > 0 {
+		width = wrapWidth
+	}
+	if state.rulerDue {
+		if showCoordinates {
+			fmt.Print(colorizer.BlankMargin())
+		}
+		fmt.Println(colorizer.Ruler(width))
+		state.rulerDue, state.rulerLines = false, 0
+	}
+
+	if showCoordinates {
+		fmt.Print(colorizer.CoordinateMargin(state.basePosition + 1))
+	}
+	fmt.Println(line)
+	state.basePosition += length
+	state.rulerLines++
+	if showRuler && state.rulerLines == rulerBlockLines {
+		state.rulerDue = true
+	}
+
+	if quality != "" {
+		if showCoordinates {
+			fmt.Print(colorizer.BlankMargin())
+		}
+		fmt.Println(colorizer.ColorizeGroupedQuality(quality))
+	}
+}
+
+// printFASTQLayout prints a FASTQ record with the --wrap, --ruler, --coordinates and
+// --group layout. Wrapped records show each sequence line directly above its quality
+// line, in place of the '+' separator layout.
+func printFASTQLayout(sequence, separator, quality string, colorizer *colorer.Colorer) {
+	state := &recordState{rulerDue: showRuler}
+	if wrapWidth > 0 {
+		printSequence(sequence, quality, wrapWidth, colorizer, state)
+		return
+	}
+
+	line := colorizer.ScanSequenceLines(sequence, quality).Line(0, len(sequence))
+	printSequenceLine(line, len(sequence), "", colorizer, state)
+	fmt.Println(separator)
+	if showCoordinates {
+		fmt.Print(colorizer.BlankMargin())
+	}
+	fmt.Println(colorizer.ColorizeGroupedQuality(quality))
+}
+
+// processFlatFileLine colorizes a GenBank or EMBL line according to the section of the
+// record it belongs to, collecting feature locations for --features
+func processFlatFileLine(line string, format parser.Format, colorizer *colorer.Colorer, state *recordState) {
+	if strings.HasPrefix(line, "//") {
+		// End of record
+		fmt.Println(colorizer.ColorizeKeywordLine(line))
+		state.flatSection = sectionHeader
+		state.features = nil
+		colorizer.SetFeatureSpans(nil)
+		return
+	}
+
+	// Sequence lines start with spaces in both formats
+	if state.flatSection == sectionSequence && strings.HasPrefix(line, " ") {
+		fmt.Println(colorizer.ColorizeNumberedSequence(line))
+		return
+	}
+
+	isFeatureLine := false
+	if format == parser.FormatGenBank {
+		switch {
+		case strings.HasPrefix(line, "FEATURES"):
+			state.flatSection = sectionFeatures
+		case strings.HasPrefix(line, "ORIGIN"):
+			state.flatSection = sectionSequence
+		case strings.HasPrefix(line, " ") && state.flatSection == sectionFeatures:
+			isFeatureLine = true
+		case !strings.HasPrefix(line, " "):
+			state.flatSection = sectionHeader
+		}
+	} else {
+		switch {
+		case strings.HasPrefix(line, "FT"):
+			state.flatSection = sectionFeatures
+			isFeatureLine = true
+		case strings.HasPrefix(line, "SQ"):
+			state.flatSection = sectionSequence
+		default:
+			state.flatSection = sectionHeader
+		}
+	}
+
+	if isFeatureLine {
+		collectFeature(line, state)
+		fmt.Println(colorizer.ColorizeFeatureLine(line))
+		return
+	}
+
+	finishFeature(state)
+	if state.flatSection == sectionSequence && showFeatures {
+		colorizer.SetFeatureSpans(state.features)
+	}
+	if strings.HasPrefix(line, " ") {
+		// Continuation of a header keyword
+		fmt.Println(line)
+	} else {
+		fmt.Println(colorizer.ColorizeKeywordLine(line))
+	}
+}
+
+// collectFeature tracks the key and location of the feature a feature table line belongs to
+func collectFeature(line string, state *recordState) {
+	if len(line) <= 21 {
+		return
+	}
+	key, value := strings.TrimSpace(line[5:21]), strings.TrimSpace(line[21:])
+
+	switch {
+	case key != "":
+		// Start of a new feature
+		finishFeature(state)
+		state.featureKey, state.featureLocation = key, value
+	case strings.HasPrefix(value, "/"):
+		// Qualifier - the location is complete
+		finishFeature(state)
+	case state.featureKey != "":
+		// Location continued on the next line
+		state.featureLocation += value
+	}
+}
+
+// finishFeature records the spans of the current feature, skipping the whole-record source
+func finishFeature(state *recordState) {
+	if state.featureKey != "" && state.featureKey != "source" {
+		state.features = append(state.features, parser.ParseLocation(state.featureKey, state.featureLocation)...)
+	}
+	state.featureKey, state.featureLocation = "", ""
+}
+
+// processAlignmentLine collects the rows of a Clustal, Stockholm or PHYLIP alignment
+// block, colorizing the block as a whole once a line outside of it is read
+func processAlignmentLine(line string, format parser.Format, colorizer *colorer.Colorer, state *recordState) {
+	var row parser.AlignmentRow
+	var isRow bool
+	switch format {
+	case parser.FormatClustal:
+		row, isRow = parser.ParseClustalRow(line)
+	case parser.FormatStockholm:
+		row, isRow = parser.ParseStockholmRow(line)
+	case parser.FormatPHYLIP:
+		if state.phylipTaxa == 0 {
+			if taxa, isHeader := parser.ParsePHYLIPHeader(line); isHeader {
+				state.phylipTaxa = taxa
+				fmt.Println(line)
+				return
+			}
+		}
+		row, isRow = parser.ParsePHYLIPRow(line, state.phylipRows < state.phylipTaxa)
+		if isRow {
+			state.phylipRows++
+		}
+	}
+
+	if isRow {
+		state.alignment = append(state.alignment, row)
+		return
+	}
+
+	if format == parser.FormatClustal && len(state.alignment) > 0 && parser.IsClustalConservationLine(line) {
+		// The conservation line belongs to the block, above the tracks
+		state.conservationLine = line
+		flushAlignment(state, colorizer)
+		return
+	}
+
+	flushAlignment(state, colorizer)
+	if strings.HasPrefix(line, "#=") {
+		// Stockholm markup - highlight the feature tag
+		fmt.Println(colorizer.ColorizeKeywordLine(line))
+	} else {
+		// Header, conservation line or block separator - print as is
+		fmt.Println(line)
+	}
+}
+
+// flushAlignment prints the collected alignment block
+func flushAlignment(state *recordState, colorizer *colorer.Colorer) {
+	if len(state.alignment) == 0 {
+		return
+	}
+
+	var residues []string
+	for _, row := range state.alignment {
+		if !row.Annotation {
+			residues = append(residues, row.Residues)
+		}
+	}
+	colored := colorizer.ColorizeAlignment(residues)
+
+	var layout *parser.AlignmentRow
+	for j, row := range state.alignment {
+		if !row.Annotation {
+			layout = &state.alignment[j]
+			break
+		}
+	}
+
+	// Tracks are labeled in the name column, widened for names longer than the prefix
+	var tracks []colorer.AlignmentTrack
+	padding := ""
+	if showTracks && layout != nil {
+		sequences := make([]string, len(residues))
+		for j, r := range residues {
+			sequences[j] = strings.ReplaceAll(r, " ", "")
+		}
+		tracks = colorizer.AlignmentTracks(sequences)
+		for _, track := range tracks {
+			if extra := len(track.Name) + 1 - len(layout.Prefix); extra > len(padding) {
+				padding = strings.Repeat(" ", extra)
+			}
+		}
+	}
+
+	i := 0
+	for _, row := range state.alignment {
+		if row.Annotation {
+			fmt.Println(row.Prefix + padding + colorizer.ColorizeAnnotation(row.Residues) + row.Suffix)
+			continue
+		}
+		fmt.Println(row.Prefix + padding + colored[i] + row.Suffix)
+		i++
+	}
+	if state.conservationLine != "" {
+		fmt.Println(padding + state.conservationLine)
+		state.conservationLine = ""
+	}
+
+	for _, track := range tracks {
+		fmt.Printf("%-*s%s\n", len(layout.Prefix)+len(padding), track.Name, track.Layout(layout.Residues, 0))
+	}
+	state.alignment = nil
+}
+
+// processAlignedFASTALine colorizes a gapped FASTA line. With --conservation or --tracks
+// the whole alignment is held back, as every column's statistics are needed first.
+func processAlignedFASTALine(line string, colorizer *colorer.Colorer, state *recordState) {
+	isHeader := strings.HasPrefix(line, ">")
+	if !conservation && !showTracks {
+		if isHeader {
+			fmt.Println(line)
+		} else {
+			fmt.Println(colorizer.ColorizeAlignment([]string{line})[0])
+		}
+		return
+	}
+
+	switch {
+	case isHeader:
+		state.alignedHeaders = append(state.alignedHeaders, line)
+		state.alignedRecords = append(state.alignedRecords, nil)
+	case len(state.alignedRecords) == 0:
+		// Text before the first record
+		fmt.Println(line)
+	default:
+		last := len(state.alignedRecords) - 1
+		state.alignedRecords[last] = append(state.alignedRecords[last], line)
+	}
+}
+
+// flushAlignedFASTA prints the aligned FASTA records held back for --conservation and
+// --tracks, followed by the tracks as extra records wrapped like the first record
+func flushAlignedFASTA(state *recordState, colorizer *colorer.Colorer) {
+	for i, lines := range colorizer.ColorizeAlignmentRecords(state.alignedRecords) {
+		fmt.Println(state.alignedHeaders[i])
+		for _, line := range lines {
+			fmt.Println(line)
+		}
+	}
+
+	if showTracks && len(state.alignedRecords) > 0 {
+		sequences := make([]string, len(state.alignedRecords))
+		for i, lines := range state.alignedRecords {
+			sequences[i] = strings.ReplaceAll(strings.Join(lines, ""), " ", "")
+		}
+		for _, track := range colorizer.AlignmentTracks(sequences) {
+			fmt.Printf(">%s\n", track.Name)
+			offset := 0
+			for _, line := range state.alignedRecords[0] {
+				fmt.Println(track.Layout(line, offset))
+				offset += len(line) - strings.Count(line, " ")
+			}
+		}
+	}
+	state.alignedHeaders, state.alignedRecords = nil, nil
+}
+
+// collectGFAStats counts the segments, links and paths of a GFA record for --gfa-summary
+func collectGFAStats(line string, state *recordState) {
+	fields := strings.Split(line, "\t")
+	switch fields[0] {
+	case "S":
+		if length, ok := parser.GFASegmentLength(fields); ok {
+			state.gfaSegments = append(state.gfaSegments, length)
+		}
+	case "L", "E":
+		state.gfaLinks++
+	case "P", "W", "O":
+		state.gfaPaths++
+	}
+}
+
+// printGFASummary prints the segment, link and path counts and the segment N50
+func printGFASummary(state *recordState) {
+	if !gfaSummary || len(state.gfaSegments)+state.gfaLinks+state.gfaPaths == 0 {
+		return
+	}
+
+	total := 0
+	for _, length := range state.gfaSegments {
+		total += length
+	}
+	fmt.Printf("Segments:     %d\n", len(state.gfaSegments))
+	fmt.Printf("Total length: %d bp\n", total)
+	fmt.Printf("N50:          %d bp\n", parser.N50(state.gfaSegments))
+	fmt.Printf("Links:        %d\n", state.gfaLinks)
+	fmt.Printf("Paths:        %d\n", state.gfaPaths)
+}
+
+// printSAMHeaderSummary prints the collected SAM header summary once, before the first record
+func printSAMHeaderSummary(state *recordState, colorizer *colorer.Colorer) {
+	if state.samHeader == nil {
+		return
+	}
+	for _, line := range colorizer.SAMHeaderSummary(state.samHeader) {
+		fmt.Println(line)
+	}
+	state.samHeader = nil
+}
+
+// flushRecord prints any lines still held back when the input ends mid-record
+func flushRecord(state *recordState, colorizer *colorer.Colorer) {
+	flushFASTARecord(colorizer, state)
+	if state.fastqLine >= 2 && (qualityMask > 0 || layoutEnabled()) {
+		fmt.Println(colorizer.ColorizeSequence(state.sequence))
+		if state.fastqLine == 3 {
+			fmt.Println(state.separator)
+		}
+	}
+	printSAMHeaderSummary(state, colorizer)
+	flushAlignment(state, colorizer)
+	flushAlignedFASTA(state, colorizer)
+	printGFASummary(state)
+	*state = recordState{}
+}
+
+// bedGraphRange returns the value range for the heat gradient from --heat-range, a
+// viewLimits track setting, or the values in the given lines, in that order
+func bedGraphRange(lines []string) (float64, float64, error) {
+	if heatRange != "" {
+		min, max, ok := parser.ParseRange(heatRange)
+		if !ok {
+			return 0, 0, fmt.Errorf("invalid heat range '%s' (use min:max)", heatRange)
+		}
+		return min, max, nil
+	}
+
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, line := range lines {
+		if strings.HasPrefix(line, "track") {
+			if low, high, ok := parser.BedGraphViewLimits(line); ok {
+				return low, high, nil
+			}
+			continue
+		}
+		if !parser.IsBedGraphLine(line) {
+			continue
+		}
+		value, _ := strconv.ParseFloat(line[strings.LastIndex(line, "\t")+1:], 64)
+		min = math.Min(min, value)
+		max = math.Max(max, value)
+	}
+	return min, max, nil
+}
+
 // formatToString converts a parser.Format to a human-readable string
 func formatToString(format parser.Format) string {
 	switch format {
@@ -236,6 +1089,32 @@ func formatToString(format parser.Format) string {
 		return "SAM"
 	case parser.FormatVCF:
 		return "VCF"
+	case parser.FormatGFF:
+		return "GFF"
+	case parser.FormatBED:
+		return "BED"
+	case parser.FormatBedGraph:
+		return "bedGraph"
+	case parser.FormatGenBank:
+		return "GenBank"
+	case parser.FormatEMBL:
+		return "EMBL"
+	case parser.FormatClustal:
+		return "Clustal"
+	case parser.FormatStockholm:
+		return "Stockholm"
+	case parser.FormatPHYLIP:
+		return "PHYLIP"
+	case parser.FormatAlignedFASTA:
+		return "aligned FASTA"
+	case parser.FormatPAF:
+		return "PAF"
+	case parser.FormatMAF:
+		return "MAF"
+	case parser.FormatPileup:
+		return "pileup"
+	case parser.FormatGFA:
+		return "GFA"
 	default:
 		return "Unknown"
 	}
@@ -244,7 +1123,7 @@ func formatToString(format parser.Format) string {
 // isSequenceCountableLine determines if a line represents a sequence entry for counting purposes
 func isSequenceCountableLine(line string, format parser.Format) bool {
 	switch format {
-	case parser.FormatFASTA:
+	case parser.FormatFASTA, parser.FormatAlignedFASTA:
 		return strings.HasPrefix(line, ">")
 	case parser.FormatFASTQ:
 		return strings.HasPrefix(line, "@") && !strings.HasPrefix(line, "+")
@@ -252,6 +1131,22 @@ func isSequenceCountableLine(line string, format parser.Format) bool {
 		return !strings.HasPrefix(line, "@") && strings.Count(line, "\t") >= 10
 	case parser.FormatVCF:
 		return !strings.HasPrefix(line, "#")
+	case parser.FormatGFF:
+		return parser.IsGFFLine(line)
+	case parser.FormatBED, parser.FormatBedGraph:
+		return parser.IsBEDLine(line)
+	case parser.FormatGenBank:
+		return strings.HasPrefix(line, "LOCUS ")
+	case parser.FormatEMBL:
+		return strings.HasPrefix(line, "ID   ")
+	case parser.FormatPAF:
+		return parser.IsPAFLine(line)
+	case parser.FormatMAF:
+		return strings.HasPrefix(line, "a")
+	case parser.FormatPileup:
+		return parser.IsPileupLine(line)
+	case parser.FormatGFA:
+		return strings.HasPrefix(line, "S\t")
 	default:
 		return false
 	}
@@ -271,4 +1166,39 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&configFile, "config", defaultConfig, "config file")
 	rootCmd.PersistentFlags().StringVarP(&colorScheme, "scheme", "s", "bright", "color scheme to use")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+
+	rootCmd.Flags().StringSliceVar(&adapters, "adapters", nil, "highlight adapter sets (e.g. illumina,nextera,smallrna,polya)")
+	rootCmd.Flags().IntVar(&qualityMask, "quality-mask", 0, "dim and lowercase bases with Phred score below this threshold (0 disables)")
+	rootCmd.Flags().StringVar(&qualityEncodingName, "quality-encoding", "auto", "FASTQ quality encoding: auto, sanger, solexa, illumina-1.3, illumina-1.5, illumina-1.8")
+	rootCmd.Flags().StringVar(&qualityDisplayName, "quality-display", "chars", "quality score display: chars, numbers or bars")
+	rootCmd.Flags().IntVar(&minDepth, "min-dp", 0, "color VCF sample DP values below/above this depth (0 disables)")
+	rootCmd.Flags().IntVar(&minGQ, "min-gq", 0, "color VCF sample GQ values below/above this quality (0 disables)")
+	rootCmd.Flags().BoolVar(&variantTypes, "variant-types", false, "color VCF ALT alleles by variant class (transition, transversion, MNV, indel, symbolic)")
+	rootCmd.Flags().StringSliceVar(&infoKeys, "info-keys", nil, "show only these VCF INFO keys (e.g. DP,AF)")
+	rootCmd.Flags().BoolVar(&infoAligned, "info-aligned", false, "list VCF INFO key=value pairs beneath each record with aligned values")
+	rootCmd.Flags().StringVar(&samFlagModeName, "sam-flags", "none", "style SAM records by FLAG: none, color or annotate")
+	rootCmd.Flags().IntVar(&maxArrayValues, "max-array", 8, "SAM B array tag values shown before abbreviating (0 shows all)")
+	rootCmd.Flags().BoolVar(&headerSummary, "header-summary", false, "summarize SAM @SQ lines and the @PG history instead of printing them")
+	rootCmd.Flags().StringVar(&heatRange, "heat-range", "", "bedGraph value range for the heat gradient as min:max (default: from the data)")
+	rootCmd.Flags().BoolVar(&showFeatures, "features", false, "project GenBank/EMBL features onto the sequence as colored spans")
+	rootCmd.Flags().BoolVar(&conservation, "conservation", false, "color only alignment residues matching their column consensus")
+	rootCmd.Flags().BoolVar(&showTracks, "tracks", false, "print consensus, identity and entropy tracks beneath alignment blocks")
+	rootCmd.Flags().Float64Var(&trackThreshold, "track-threshold", 0.8, "identity at which consensus track residues are highlighted")
+	rootCmd.Flags().IntVar(&maxSegment, "max-segment", 0, "GFA segment bases shown before truncating (0 shows whole segments)")
+	rootCmd.Flags().BoolVar(&gfaSummary, "gfa-summary", false, "print GFA segment/link/path counts and N50 instead of the records")
+	rootCmd.Flags().IntVar(&wrapWidth, "wrap", 0, "reflow FASTA/FASTQ sequences to N bases per line (0 keeps the input lines)")
+	rootCmd.Flags().BoolVar(&showRuler, "ruler", false, "print a position ruler above every 10 FASTA/FASTQ sequence lines")
+	rootCmd.Flags().BoolVar(&showCoordinates, "coordinates", false, "prefix FASTA/FASTQ sequence lines with the position of their first base")
+	rootCmd.Flags().IntVar(&groupSize, "group", 0, "separate sequence and quality strings into groups of N bases (e.g. 10)")
+	rootCmd.Flags().IntVar(&groupGap, "group-gap", 0, "bases per larger block of groups, e.g. 50 or 60 (default: five groups, 0 disables)")
+	rootCmd.Flags().BoolVar(&reverseComplement, "revcomp", false, "show the reverse complement of FASTA/FASTQ records")
+	rootCmd.Flags().BoolVar(&transcribe, "transcribe", false, "show FASTA/FASTQ DNA sequences as RNA (T to U)")
+	rootCmd.Flags().BoolVar(&backTranscribe, "back-transcribe", false, "show FASTA/FASTQ RNA sequences as DNA (U to T)")
+	rootCmd.Flags().IntVar(&homopolymerMin, "homopolymer", 0, "highlight homopolymer runs of at least N bases (0 disables)")
+	rootCmd.Flags().IntVar(&tandemMin, "tandem", 0, "highlight tandem repeats with periods 2-6 of at least N bases (0 disables)")
+	rootCmd.Flags().StringVar(&repeatStyleName, "repeat-style", "underline", "repeat highlight style: underline or background")
+	rootCmd.Flags().BoolVar(&repeatLengths, "repeat-lengths", false, "annotate the length of each highlighted repeat")
+	rootCmd.Flags().StringVar(&colorModeName, "mode", "nucleotide", "base coloring: nucleotide, gc (local GC fraction) or cpg (CpG sites and islands)")
+	rootCmd.Flags().IntVar(&windowSize, "window", 50, "sliding window in bases for --mode gc and cpg")
+	rootCmd.Flags().StringSliceVar(&enzymes, "enzymes", nil, "highlight restriction sites with cut markers (e.g. EcoRI,BamHI)")
 }
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/benekenobi/colordna/internal/colorer"
+)
+
+func TestCheckQualityLayout(t *testing.T) {
+	tests := []struct {
+		name    string
+		display colorer.QualityDisplay
+		wrap    int
+		group   int
+		wantErr bool
+	}{
+		{"numbers without layout", colorer.QualityNumbers, 0, 0, false},
+		{"numbers with wrap", colorer.QualityNumbers, 60, 0, true},
+		{"numbers with group", colorer.QualityNumbers, 0, 10, true},
+		{"chars with wrap and group", colorer.QualityChars, 60, 10, false},
+		{"bars with wrap", colorer.QualityBars, 60, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkQualityLayout(tt.display, tt.wrap, tt.group)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkQualityLayout(%v, %d, %d) error = %v, wantErr %v", tt.display, tt.wrap, tt.group, err, tt.wantErr)
+			}
+		})
+	}
+}
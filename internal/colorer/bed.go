@@ -0,0 +1,151 @@
+package colorer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	trackCode = "\033[1m\033[35m" // Bold magenta track and browser keywords
+
+	// bedScoreMax is the highest BED score
+	bedScoreMax = 1000
+)
+
+// SetHeatRange sets the value range mapped onto the heat gradient for bedGraph values
+func (c *Colorer) SetHeatRange(min, max float64) {
+	c.heatMin, c.heatMax = min, max
+}
+
+// heatColor returns the gradient color for a position between 0 and 1, using the
+// truecolor quality gradient when configured and the quality bins otherwise
+func (c *Colorer) heatColor(pos float64) string {
+	if len(c.gradientStops) >= 2 {
+		return c.gradientAt(pos)
+	}
+	return c.getQualityColor(int(pos * qualityBarMax))
+}
+
+// ColorizeTrackLine highlights the keyword of a track or browser line
+func (c *Colorer) ColorizeTrackLine(line string) string {
+	keyword, rest, _ := strings.Cut(line, " ")
+	if rest == "" {
+		return trackCode + keyword + resetCode
+	}
+	return trackCode + keyword + resetCode + " " + rest
+}
+
+// ColorizeBED colorizes a BED record. Rows with an itemRgb value are drawn in that color.
+func (c *Colorer) ColorizeBED(line string) string {
+	fields := strings.Split(line, "\t")
+	if len(fields) < 3 {
+		return line
+	}
+
+	// Field 8 (index 8) contains itemRgb, which colors the whole row
+	if len(fields) > 8 {
+		if code := c.itemRgbCode(fields[8]); code != "" {
+			return code + line + resetCode
+		}
+	}
+
+	fields[0] = seqidCode + fields[0] + resetCode
+	if len(fields) > 3 {
+		fields[3] = attrKeyCode + fields[3] + resetCode
+	}
+	if len(fields) > 4 {
+		if score, err := strconv.ParseFloat(fields[4], 64); err == nil {
+			fields[4] = c.heatColor(score/bedScoreMax) + fields[4] + resetCode
+		}
+	}
+	if len(fields) > 5 {
+		fields[5] = colorizeStrand(fields[5])
+	}
+	for i := 6; i < len(fields); i++ {
+		fields[i] = sourceCode + fields[i] + resetCode
+	}
+
+	return strings.Join(fields, "\t")
+}
+
+// ColorizeBedGraph colorizes a bedGraph record, coloring its value by the heat range
+func (c *Colorer) ColorizeBedGraph(line string) string {
+	fields := strings.Split(line, "\t")
+	if len(fields) != 4 {
+		return line
+	}
+
+	fields[0] = seqidCode + fields[0] + resetCode
+	if value, err := strconv.ParseFloat(fields[3], 64); err == nil && c.heatMax > c.heatMin {
+		pos := (value - c.heatMin) / (c.heatMax - c.heatMin)
+		fields[3] = c.heatColor(pos) + fields[3] + resetCode
+	}
+
+	return strings.Join(fields, "\t")
+}
+
+// SetTruecolor sets whether the terminal shows 24-bit colors. Without them, itemRgb
+// colors are drawn with the nearest color of the 256-color palette.
+func (c *Colorer) SetTruecolor(enabled bool) {
+	c.truecolor = enabled
+}
+
+// itemRgbCode converts an "r,g,b" itemRgb value to an escape sequence, returning an
+// empty string for "0" or invalid values
+func (c *Colorer) itemRgbCode(value string) string {
+	parts := strings.Split(value, ",")
+	if len(parts) != 3 {
+		return ""
+	}
+	var rgb [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || n > 255 {
+			return ""
+		}
+		rgb[i] = n
+	}
+	if !c.truecolor {
+		return fmt.Sprintf("\033[38;5;%dm", ansi256(rgb[0], rgb[1], rgb[2]))
+	}
+	return truecolorCode(rgb[0], rgb[1], rgb[2])
+}
+
+// cubeLevels are the channel intensities of the 6x6x6 cube of the 256-color palette
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// ansi256 returns the 256-color palette index nearest to an RGB color, from the color
+// cube (16-231) or the grayscale ramp (232-255)
+func ansi256(r, g, b int) int {
+	var cube [3]int
+	for i, value := range [3]int{r, g, b} {
+		for level := range cubeLevels {
+			if abs(value-cubeLevels[level]) < abs(value-cubeLevels[cube[i]]) {
+				cube[i] = level
+			}
+		}
+	}
+	index := 16 + 36*cube[0] + 6*cube[1] + cube[2]
+	distance := colorDistance(r, g, b, cubeLevels[cube[0]], cubeLevels[cube[1]], cubeLevels[cube[2]])
+
+	gray := min(max((r+g+b)/3-3, 0)/10, 23)
+	level := 8 + 10*gray
+	if colorDistance(r, g, b, level, level, level) < distance {
+		return 232 + gray
+	}
+	return index
+}
+
+// colorDistance returns the squared distance between two RGB colors
+func colorDistance(r1, g1, b1, r2, g2, b2 int) int {
+	return (r1-r2)*(r1-r2) + (g1-g2)*(g1-g2) + (b1-b2)*(b1-b2)
+}
+
+// abs returns the absolute value of n
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,50 @@
+package colorer
+
+import "testing"
+
+func TestANSI256(t *testing.T) {
+	tests := []struct {
+		name    string
+		r, g, b int
+		want    int
+	}{
+		{"black", 0, 0, 0, 16},
+		{"white", 255, 255, 255, 231},
+		{"red", 255, 0, 0, 196},
+		{"ucsc blue", 0, 0, 255, 21},
+		{"near cube level", 100, 140, 170, 67},
+		{"mid gray", 128, 128, 128, 244},
+		{"dark gray", 30, 30, 30, 234},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ansi256(tt.r, tt.g, tt.b); got != tt.want {
+				t.Errorf("ansi256(%d, %d, %d) = %d, want %d", tt.r, tt.g, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestItemRgbCode(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		truecolor bool
+		want      string
+	}{
+		{"truecolor", "255,0,0", true, "\033[38;2;255;0;0m"},
+		{"256 colors", "255,0,0", false, "\033[38;5;196m"},
+		{"unset", "0", false, ""},
+		{"out of range", "256,0,0", true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Colorer{truecolor: tt.truecolor}
+			if got := c.itemRgbCode(tt.value); got != tt.want {
+				t.Errorf("itemRgbCode(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package colorer
 import (
 	"fmt"
 	"strings"
+	"unicode"
 
 	"github.com/benekenobi/colordna/internal/config"
 	"github.com/benekenobi/colordna/internal/parser"
@@ -10,29 +11,177 @@ import (
 
 const (
 	resetCode = "\033[0m"
+	dimCode   = "\033[2m"
 )
 
 // Colorer handles the coloring of sequences and quality scores
 type Colorer struct {
-	scheme config.ColorScheme
+	scheme         config.ColorScheme
+	motifs         []Motif
+	qualityMask    int                    // Bases below this Phred score are dimmed, 0 disables masking
+	encoding       parser.QualityEncoding // Quality character encoding, Phred+33 when unknown
+	display        QualityDisplay         // How quality scores are rendered
+	minDepth       int                    // VCF sample DP threshold, 0 disables DP coloring
+	minGQ          int                    // VCF sample GQ threshold, 0 disables GQ coloring
+	variantTypes   bool                   // Color VCF ALT alleles by variant class
+	vcfHeader      *parser.VCFHeader      // INFO/FORMAT definitions of the current VCF
+	infoKeys       []string               // INFO keys to show, all when empty
+	infoAligned    bool                   // List INFO pairs beneath each record
+	samFlags       SAMFlagMode            // How SAM records are styled by FLAG
+	maxArrayValues int                    // SAM B array values shown before abbreviating, 0 shows all
+
+	qualityBins     []config.QualityBin     // Quality bins sorted by descending Min
+	qualityGradient *config.QualityGradient // Continuous gradient, overrides the bins
+	gradientStops   [][3]uint8              // Parsed gradient stops
+	heatMin         float64                 // bedGraph value mapped to the bottom of the gradient
+	heatMax         float64                 // bedGraph value mapped to the top of the gradient
+	truecolor       bool                    // The terminal shows 24-bit itemRgb colors
+	featureSpans    []parser.FeatureSpan    // GenBank/EMBL features projected onto the sequence
+	conservation    bool                    // Color only alignment residues matching the consensus
+	alphabet        Alphabet                // Residue colors of alignments, detected per block when auto
+	trackThreshold  float64                 // Identity at which consensus track residues are highlighted
+	maxSegment      int                     // GFA segment bases shown before truncating, 0 shows all
+	groupSize       int                     // Bases per separated group, 0 disables grouping
+	groupGap        int                     // Bases per larger block of groups, 0 disables the gap
+
+	reverseComplement bool          // Show the opposite strand of FASTA/FASTQ records
+	transcription     Transcription // DNA/RNA conversion of FASTA/FASTQ records
+	homopolymerMin    int           // Shortest highlighted homopolymer run, 0 disables
+	tandemMin         int           // Shortest highlighted tandem repeat, 0 disables
+	repeatStyle       RepeatStyle   // How repeats are highlighted
+	repeatLengths     bool          // Annotate the length of each repeat after it
+	mode              ColorMode     // What the color of each base shows
+	window            int           // Sliding window of the GC and CpG modes, in bases
 }
 
 // New creates a new Colorer with the given color scheme
 func New(scheme config.ColorScheme) *Colorer {
-	return &Colorer{scheme: scheme}
+	c := &Colorer{scheme: scheme}
+	c.qualityBins, c.qualityGradient = scheme.ResolveQuality()
+	if c.qualityGradient != nil {
+		for _, stop := range c.qualityGradient.Stops {
+			r, g, b, err := config.ParseHexColor(stop)
+			if err != nil {
+				continue
+			}
+			c.gradientStops = append(c.gradientStops, [3]uint8{r, g, b})
+		}
+	}
+	return c
+}
+
+// SetQualityMask sets the Phred score below which bases are rendered dim and lowercase
+func (c *Colorer) SetQualityMask(threshold int) {
+	c.qualityMask = threshold
+}
+
+// SetQualityEncoding sets the encoding used to convert quality characters to Phred scores
+func (c *Colorer) SetQualityEncoding(encoding parser.QualityEncoding) {
+	c.encoding = encoding
 }
 
 // ColorizeSequence colorizes a DNA/RNA/protein sequence
 func (c *Colorer) ColorizeSequence(sequence string) string {
+	return c.colorizeBases(sequence, "")
+}
+
+// ColorizeMaskedSequence colorizes a sequence, dimming bases whose score in the
+// matching quality string is below the quality mask threshold
+func (c *Colorer) ColorizeMaskedSequence(sequence, quality string) string {
+	if c.qualityMask <= 0 || len(quality) != len(sequence) {
+		return c.ColorizeSequence(sequence)
+	}
+	return c.colorizeBases(sequence, quality)
+}
+
+// SequenceLines is a whole sequence scanned for motifs, repeats and mode colors, so that
+// it can be rendered one line at a time without holding every colored line in memory
+type SequenceLines struct {
+	colorer *Colorer
+	scan    *sequenceScan
+	quality string
+}
+
+// ScanSequenceLines scans a whole sequence so that motifs and repeats are found across
+// the line breaks. Bases are masked by quality as in ColorizeMaskedSequence.
+func (c *Colorer) ScanSequenceLines(sequence, quality string) *SequenceLines {
+	if c.qualityMask <= 0 || len(quality) != len(sequence) {
+		quality = ""
+	}
+	return &SequenceLines{colorer: c, scan: c.scanSequence(sequence), quality: quality}
+}
+
+// Line colorizes the bases from..to, with bases grouped by SetGrouping. Lines must be
+// rendered in order.
+func (s *SequenceLines) Line(from, to int) string {
+	return s.colorer.renderBases(s.scan, s.quality, from, min(to, len(s.scan.upper)), true)
+}
+
+// ScansWholeSequence reports whether the colors of a base depend on the bases around it,
+// such as motifs, repeats or GC windows spanning line breaks, so that multi-line records
+// should be colorized whole
+func (c *Colorer) ScansWholeSequence() bool {
+	return len(c.motifs) > 0 || c.homopolymerMin > 0 || c.tandemMin > 0 || c.mode != ModeNucleotide
+}
+
+// colorizeBases colorizes a sequence, masking bases by quality when quality is not empty
+func (c *Colorer) colorizeBases(sequence, quality string) string {
 	if len(sequence) == 0 {
 		return sequence
 	}
+	return c.renderBases(c.scanSequence(sequence), quality, 0, len(sequence), false)
+}
+
+// sequenceScan holds the motifs and repeats found in a whole sequence, so that it can be
+// rendered in parts, in order
+type sequenceScan struct {
+	upper   string
+	covered []bool         // Positions inside motifs
+	cuts    []bool         // Positions preceded by a cut site, one more than the sequence
+	repeats []bool         // Positions inside homopolymer runs and tandem repeats
+	notes   map[int]string // Repeat annotations keyed by the position they follow
+	window  *windowCounts  // Sliding window of the GC and CpG modes, nil otherwise
+}
+
+// scanSequence finds the motifs, repeats and mode colors of a sequence
+func (c *Colorer) scanSequence(sequence string) *sequenceScan {
+	scan := &sequenceScan{upper: strings.ToUpper(sequence)}
+	scan.covered, scan.cuts = c.findMotifs(scan.upper)
+	scan.repeats, scan.notes = c.findRepeats(scan.upper)
+	scan.window = c.newWindowCounts(scan.upper)
+	return scan
+}
 
+// renderBases colorizes the bases from..to of a scanned sequence, inserting group
+// separators when grouped
+func (c *Colorer) renderBases(scan *sequenceScan, quality string, from, to int, grouped bool) string {
 	var result strings.Builder
-	result.Grow(len(sequence) * 10) // Pre-allocate for efficiency
+	result.Grow((to - from) * 10) // Pre-allocate for efficiency
+
+	for i := from; i < to; i++ {
+		if grouped {
+			result.WriteString(c.groupSeparator(i - from))
+		}
+		if scan.cuts != nil && scan.cuts[i] {
+			result.WriteString(cutMarkerCode)
+			result.WriteString(resetCode)
+		}
 
-	for _, char := range strings.ToUpper(sequence) {
+		char := rune(scan.upper[i])
 		color := c.getColorForNucleotide(char)
+		if scan.window != nil {
+			color = c.modeColor(scan.window, i)
+		}
+		if scan.covered != nil && scan.covered[i] {
+			color += motifCode
+		}
+		if scan.repeats != nil && scan.repeats[i] {
+			color += c.repeatCode()
+		}
+		if quality != "" && c.encoding.Phred(rune(quality[i])) < c.qualityMask {
+			color += dimCode
+			char = unicode.ToLower(char)
+		}
 		if color != "" {
 			result.WriteString(color)
 			result.WriteRune(char)
@@ -40,6 +189,11 @@ func (c *Colorer) ColorizeSequence(sequence string) string {
 		} else {
 			result.WriteRune(char)
 		}
+		result.WriteString(scan.notes[i+1])
+	}
+	if to == len(scan.upper) && scan.cuts != nil && scan.cuts[to] {
+		result.WriteString(cutMarkerCode)
+		result.WriteString(resetCode)
 	}
 
 	return result.String()
@@ -47,68 +201,29 @@ func (c *Colorer) ColorizeSequence(sequence string) string {
 
 // ColorizeQuality colorizes quality scores in FASTQ format
 func (c *Colorer) ColorizeQuality(quality string) string {
+	return c.colorizeQuality(quality, false)
+}
+
+// ColorizeGroupedQuality colorizes a FASTQ quality line with the grouping of
+// SequenceLines, so that each score stays under its base
+func (c *Colorer) ColorizeGroupedQuality(quality string) string {
+	return c.colorizeQuality(quality, true)
+}
+
+// colorizeQuality colorizes quality scores, inserting group separators when grouped
+func (c *Colorer) colorizeQuality(quality string, grouped bool) string {
 	if len(quality) == 0 {
 		return quality
 	}
 
 	if c.scheme.Quality == "gradient" {
-		return c.colorizeQualityGradient(quality)
+		return c.colorizeQualityGradient(quality, grouped)
 	} else if c.scheme.Quality == "mono" {
-		return c.colorizeQualityMono(quality)
+		return c.colorizeQualityMono(quality, grouped)
 	}
 
 	// Default: no coloring
-	return quality
-}
-
-// ColorizeSAM colorizes the sequence column in SAM format
-func (c *Colorer) ColorizeSAM(line string) string {
-	fields := strings.Split(line, "\t")
-	if len(fields) < 11 {
-		return line // Not enough fields for SAM format
-	}
-
-	// Field 9 (index 9) contains the sequence
-	sequence := fields[9]
-	if sequence != "*" && parser.IsSequenceLine(sequence) {
-		fields[9] = c.ColorizeSequence(sequence)
-	}
-
-	// Field 10 (index 10) contains the quality scores
-	if len(fields) > 10 {
-		quality := fields[10]
-		if quality != "*" && parser.IsQualityLine(quality) {
-			fields[10] = c.ColorizeQuality(quality)
-		}
-	}
-
-	return strings.Join(fields, "\t")
-}
-
-// ColorizeVCF colorizes relevant fields in VCF format
-func (c *Colorer) ColorizeVCF(line string) string {
-	fields := strings.Split(line, "\t")
-	if len(fields) < 5 {
-		return line // Not enough fields for VCF format
-	}
-
-	// Field 3 (index 3) contains the reference allele
-	if ref := fields[3]; ref != "." && parser.IsSequenceLine(ref) {
-		fields[3] = c.ColorizeSequence(ref)
-	}
-
-	// Field 4 (index 4) contains the alternative allele(s)
-	if alt := fields[4]; alt != "." {
-		alternatives := strings.Split(alt, ",")
-		for i, allele := range alternatives {
-			if parser.IsSequenceLine(allele) {
-				alternatives[i] = c.ColorizeSequence(allele)
-			}
-		}
-		fields[4] = strings.Join(alternatives, ",")
-	}
-
-	return strings.Join(fields, "\t")
+	return c.renderQuality(quality, grouped)
 }
 
 // getColorForNucleotide returns the ANSI color code for a nucleotide
@@ -136,21 +251,24 @@ func (c *Colorer) getColorForNucleotide(nucleotide rune) string {
 }
 
 // colorizeQualityGradient applies a gradient color to quality scores
-func (c *Colorer) colorizeQualityGradient(quality string) string {
+func (c *Colorer) colorizeQualityGradient(quality string, grouped bool) string {
 	var result strings.Builder
 	result.Grow(len(quality) * 10)
 
-	for _, char := range quality {
+	for i, char := range quality {
+		if grouped {
+			result.WriteString(c.groupSeparator(i))
+		}
 		// Convert quality character to Phred score
-		phred := int(char) - 33 // Standard Phred+33 encoding
+		phred := c.encoding.Phred(char)
 
 		color := c.getQualityColor(phred)
 		if color != "" {
 			result.WriteString(color)
-			result.WriteRune(char)
+			result.WriteString(c.qualitySymbol(i, char, phred))
 			result.WriteString(resetCode)
 		} else {
-			result.WriteRune(char)
+			result.WriteString(c.qualitySymbol(i, char, phred))
 		}
 	}
 
@@ -158,24 +276,18 @@ func (c *Colorer) colorizeQualityGradient(quality string) string {
 }
 
 // colorizeQualityMono applies monochrome styling to quality scores
-func (c *Colorer) colorizeQualityMono(quality string) string {
+func (c *Colorer) colorizeQualityMono(quality string, grouped bool) string {
 	var result strings.Builder
 	result.Grow(len(quality) * 10)
 
-	for _, char := range quality {
-		phred := int(char) - 33
-
-		var style string
-		if phred >= 30 {
-			style = "\033[1m" // Bold for high quality
-		} else if phred >= 20 {
-			style = "\033[0m" // Normal for medium quality
-		} else {
-			style = "\033[2m" // Dim for low quality
+	for i, char := range quality {
+		if grouped {
+			result.WriteString(c.groupSeparator(i))
 		}
+		phred := c.encoding.Phred(char)
 
-		result.WriteString(style)
-		result.WriteRune(char)
+		result.WriteString(c.getQualityColor(phred))
+		result.WriteString(c.qualitySymbol(i, char, phred))
 		result.WriteString(resetCode)
 	}
 
@@ -184,19 +296,55 @@ func (c *Colorer) colorizeQualityMono(quality string) string {
 
 // getQualityColor returns color based on Phred quality score
 func (c *Colorer) getQualityColor(phred int) string {
-	// Quality color gradient from red (low) to green (high)
-	switch {
-	case phred >= 40:
-		return "\033[92m" // Bright green - excellent quality
-	case phred >= 30:
-		return "\033[32m" // Green - good quality
-	case phred >= 20:
-		return "\033[93m" // Yellow - acceptable quality
-	case phred >= 10:
-		return "\033[91m" // Red - poor quality
-	default:
-		return "\033[31m" // Dark red - very poor quality
+	if len(c.gradientStops) >= 2 {
+		return c.getGradientColor(phred)
+	}
+
+	for _, bin := range c.qualityBins {
+		if phred >= bin.Min {
+			return bin.Color
+		}
+	}
+	if len(c.qualityBins) > 0 {
+		// Scores below the lowest bin use its color
+		return c.qualityBins[len(c.qualityBins)-1].Color
+	}
+	return ""
+}
+
+// getGradientColor interpolates a truecolor escape sequence for a Phred score
+func (c *Colorer) getGradientColor(phred int) string {
+	g := c.qualityGradient
+	return c.gradientAt(float64(phred-g.Min) / float64(g.Max-g.Min))
+}
+
+// gradientAt interpolates a truecolor escape sequence at a position between 0 and 1
+func (c *Colorer) gradientAt(pos float64) string {
+	if pos < 0 {
+		pos = 0
+	} else if pos > 1 {
+		pos = 1
+	}
+
+	// Locate the pair of stops surrounding the position
+	scaled := pos * float64(len(c.gradientStops)-1)
+	lower := int(scaled)
+	if lower >= len(c.gradientStops)-1 {
+		lower = len(c.gradientStops) - 2
+	}
+	frac := scaled - float64(lower)
+	from, to := c.gradientStops[lower], c.gradientStops[lower+1]
+
+	var rgb [3]int
+	for i := range rgb {
+		rgb[i] = int(float64(from[i]) + (float64(to[i])-float64(from[i]))*frac + 0.5)
 	}
+	return truecolorCode(rgb[0], rgb[1], rgb[2])
+}
+
+// truecolorCode returns the escape sequence for a 24-bit foreground color
+func truecolorCode(r, g, b int) string {
+	return fmt.Sprintf("\033[38;2;%d;%d;%dm", r, g, b)
 }
 
 // ColorizeText colorizes any text that contains DNA/RNA sequences
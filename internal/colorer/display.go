@@ -0,0 +1,78 @@
+package colorer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// QualityDisplay controls how quality scores are rendered
+type QualityDisplay int
+
+const (
+	QualityChars   QualityDisplay = iota // Original quality characters
+	QualityNumbers                       // Space-aligned Phred values
+	QualityBars                          // Unicode block glyphs
+)
+
+// qualityBarGlyphs are the block glyphs used for QualityBars, from lowest to highest
+var qualityBarGlyphs = []rune("▁▂▃▄▅▆▇█")
+
+// qualityBarMax is the Phred score rendered as a full block
+const qualityBarMax = 40
+
+// ParseQualityDisplay parses a quality display name: chars, numbers or bars
+func ParseQualityDisplay(name string) (QualityDisplay, error) {
+	switch strings.ToLower(name) {
+	case "chars", "":
+		return QualityChars, nil
+	case "numbers":
+		return QualityNumbers, nil
+	case "bars":
+		return QualityBars, nil
+	default:
+		return QualityChars, fmt.Errorf("unknown quality display '%s' (use chars, numbers or bars)", name)
+	}
+}
+
+// SetQualityDisplay sets how ColorizeQuality renders quality scores
+func (c *Colorer) SetQualityDisplay(display QualityDisplay) {
+	c.display = display
+}
+
+// qualitySymbol returns the text shown for the quality character at index i
+func (c *Colorer) qualitySymbol(i int, char rune, phred int) string {
+	switch c.display {
+	case QualityNumbers:
+		if i > 0 {
+			return fmt.Sprintf(" %2d", phred)
+		}
+		return fmt.Sprintf("%2d", phred)
+	case QualityBars:
+		level := phred * len(qualityBarGlyphs) / (qualityBarMax + 1)
+		if level < 0 {
+			level = 0
+		} else if level >= len(qualityBarGlyphs) {
+			level = len(qualityBarGlyphs) - 1
+		}
+		return string(qualityBarGlyphs[level])
+	default:
+		return string(char)
+	}
+}
+
+// renderQuality converts a quality string to the display format without coloring,
+// inserting group separators when grouped
+func (c *Colorer) renderQuality(quality string, grouped bool) string {
+	if c.display == QualityChars && (!grouped || c.groupSize == 0) {
+		return quality
+	}
+
+	var result strings.Builder
+	for i, char := range quality {
+		if grouped {
+			result.WriteString(c.groupSeparator(i))
+		}
+		result.WriteString(c.qualitySymbol(i, char, c.encoding.Phred(char)))
+	}
+	return result.String()
+}
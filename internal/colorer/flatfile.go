@@ -0,0 +1,168 @@
+package colorer
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/benekenobi/colordna/internal/parser"
+)
+
+const (
+	keywordCode   = "\033[1m"  // Bold header keywords and EMBL line codes
+	qualifierCode = "\033[36m" // Cyan feature qualifier names
+	positionCode  = "\033[90m" // Dark gray sequence position numbers
+
+	// featureKeyColumn and featureValueColumn delimit the key in a feature table line
+	featureKeyColumn   = 5
+	featureValueColumn = 21
+)
+
+// featureBackgrounds maps feature keys to the background used when projecting them
+// onto the sequence, other features use defaultFeatureBackground
+var featureBackgrounds = map[string]string{
+	"gene": "\033[44m", // Blue
+	"mrna": "\033[45m", // Magenta
+	"exon": "\033[42m", // Green
+	"cds":  "\033[43m", // Yellow
+}
+
+const defaultFeatureBackground = "\033[100m" // Dark gray
+
+// SetFeatureSpans sets the feature spans projected onto numbered sequence lines.
+// Later spans take precedence over earlier ones where they overlap.
+func (c *Colorer) SetFeatureSpans(spans []parser.FeatureSpan) {
+	c.featureSpans = spans
+}
+
+// ColorizeKeywordLine bolds the leading keyword of a GenBank header line or the
+// line code of an EMBL line
+func (c *Colorer) ColorizeKeywordLine(line string) string {
+	end := strings.IndexByte(line, ' ')
+	if end < 0 {
+		return keywordCode + line + resetCode
+	}
+	return keywordCode + line[:end] + resetCode + line[end:]
+}
+
+// ColorizeFeatureLine colorizes a feature table line, where the feature key occupies
+// columns 6-21 and locations or /qualifiers start at column 22. The first five columns
+// are blank in GenBank and hold the "FT" line code in EMBL.
+func (c *Colorer) ColorizeFeatureLine(line string) string {
+	if len(line) <= featureKeyColumn {
+		return line
+	}
+
+	prefix := line[:featureKeyColumn]
+	if strings.TrimSpace(prefix) != "" {
+		prefix = c.ColorizeKeywordLine(prefix)
+	}
+
+	var key, value string
+	if len(line) > featureValueColumn {
+		key, value = line[featureKeyColumn:featureValueColumn], line[featureValueColumn:]
+	} else {
+		key = line[featureKeyColumn:]
+	}
+
+	if name := strings.TrimSpace(key); name != "" {
+		code, ok := featureTypeCodes[strings.ToLower(name)]
+		if !ok {
+			code = attrKeyCode
+		}
+		key = strings.Replace(key, name, code+name+resetCode, 1)
+	}
+
+	if strings.HasPrefix(value, "/") {
+		// Qualifier - color the name up to '='
+		name, rest, found := strings.Cut(value, "=")
+		value = qualifierCode + name + resetCode
+		if found {
+			value += "=" + rest
+		}
+	} else if strings.Contains(value, "complement") {
+		// Location - highlight the reverse strand
+		value = strings.ReplaceAll(value, "complement", minusCode+"complement"+resetCode)
+	}
+
+	return prefix + key + value
+}
+
+// ColorizeNumberedSequence colorizes a GenBank ORIGIN or EMBL SQ sequence line,
+// keeping the spacing and graying out the position numbers. GenBank lines start
+// with the position of their first base, EMBL lines end with that of their last.
+func (c *Colorer) ColorizeNumberedSequence(line string) string {
+	tokens := splitKeepingSpaces(line)
+
+	bases := 0
+	firstNumber, lastNumber := -1, -1
+	for i, token := range tokens {
+		if strings.TrimSpace(token) == "" {
+			continue
+		}
+		if n, err := strconv.Atoi(token); err == nil {
+			if firstNumber < 0 && bases == 0 {
+				firstNumber = n
+			}
+			lastNumber = n
+			tokens[i] = positionCode + token + resetCode
+			continue
+		}
+		bases += len(token)
+	}
+
+	// Position of the first base on the line, or 0 if unknown
+	position := 0
+	if firstNumber > 0 {
+		position = firstNumber
+	} else if lastNumber > 0 {
+		position = lastNumber - bases + 1
+	}
+
+	for i, token := range tokens {
+		if strings.TrimSpace(token) == "" || strings.HasPrefix(token, positionCode) {
+			continue
+		}
+		if len(c.featureSpans) == 0 || position <= 0 {
+			tokens[i] = c.ColorizeSequence(token)
+			continue
+		}
+
+		var result strings.Builder
+		for j := 0; j < len(token); j++ {
+			result.WriteString(c.featureBackground(position + j))
+			result.WriteString(c.ColorizeSequence(token[j : j+1]))
+		}
+		tokens[i] = result.String()
+		position += len(token)
+	}
+
+	return strings.Join(tokens, "")
+}
+
+// featureBackground returns the background of the last feature covering a position
+func (c *Colorer) featureBackground(position int) string {
+	for i := len(c.featureSpans) - 1; i >= 0; i-- {
+		span := c.featureSpans[i]
+		if position < span.Start || position > span.End {
+			continue
+		}
+		if code, ok := featureBackgrounds[strings.ToLower(span.Key)]; ok {
+			return code
+		}
+		return defaultFeatureBackground
+	}
+	return ""
+}
+
+// splitKeepingSpaces splits a line into alternating runs of spaces and non-spaces
+func splitKeepingSpaces(line string) []string {
+	var tokens []string
+	start := 0
+	for i := 1; i <= len(line); i++ {
+		if i == len(line) || (line[i] == ' ') != (line[i-1] == ' ') {
+			tokens = append(tokens, line[start:i])
+			start = i
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,150 @@
+package colorer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ColorMode selects what the color of each base shows
+type ColorMode int
+
+const (
+	ModeNucleotide ColorMode = iota // Color by nucleotide, using the scheme
+	ModeGC                          // Color by GC fraction of the surrounding window
+	ModeCpG                         // Highlight CpG dinucleotides and CpG island windows
+)
+
+const (
+	cpgCode       = "\033[1m\033[93m" // Bold bright yellow - CpG dinucleotide
+	cpgOtherCode  = "\033[90m"        // Dark gray - bases outside CpG dinucleotides
+	cpgIslandCode = "\033[48;5;22m"   // Dark green background - CpG island window
+)
+
+const (
+	// cpgIslandGC is the GC fraction a CpG island window must exceed
+	cpgIslandGC = 0.5
+	// cpgIslandRatio is the observed/expected CpG ratio a CpG island window must exceed
+	cpgIslandRatio = 0.6
+)
+
+// ParseColorMode parses a color mode name: nucleotide, gc or cpg
+func ParseColorMode(name string) (ColorMode, error) {
+	switch strings.ToLower(name) {
+	case "nucleotide", "":
+		return ModeNucleotide, nil
+	case "gc":
+		return ModeGC, nil
+	case "cpg":
+		return ModeCpG, nil
+	default:
+		return ModeNucleotide, fmt.Errorf("unknown color mode '%s' (use nucleotide, gc or cpg)", name)
+	}
+}
+
+// SetColorMode sets what ColorizeSequence colors show and the sliding window, in bases,
+// used by the GC and CpG modes
+func (c *Colorer) SetColorMode(mode ColorMode, window int) {
+	c.mode = mode
+	c.window = window
+}
+
+// windowCounts holds the nucleotide counts of a window sliding along a sequence. The
+// window only moves forward, so a base is counted and uncounted once.
+type windowCounts struct {
+	upper      string
+	start, end int // Bases counted, [start, end)
+	cpgEnd     int // CpG dinucleotides counted by their C, [start, cpgEnd)
+	acgt, c, g int
+	gc, cpgs   int
+}
+
+// isCpG reports whether a CpG dinucleotide starts at position i
+func isCpG(upper string, i int) bool {
+	return i+1 < len(upper) && upper[i] == 'C' && upper[i+1] == 'G'
+}
+
+// moveTo moves the window forward to [start, end)
+func (w *windowCounts) moveTo(start, end int) {
+	for ; w.end < end; w.end++ {
+		w.count(w.upper[w.end], 1)
+	}
+	// CpGs starting at the last base of the window end outside it
+	for ; w.cpgEnd < end-1; w.cpgEnd++ {
+		if isCpG(w.upper, w.cpgEnd) {
+			w.cpgs++
+		}
+	}
+	for ; w.start < start; w.start++ {
+		w.count(w.upper[w.start], -1)
+		if isCpG(w.upper, w.start) {
+			w.cpgs--
+		}
+	}
+}
+
+// count adds delta to the counts of a base
+func (w *windowCounts) count(base byte, delta int) {
+	switch base {
+	case 'C':
+		w.c += delta
+		w.gc += delta
+		w.acgt += delta
+	case 'G':
+		w.g += delta
+		w.gc += delta
+		w.acgt += delta
+	case 'A', 'T', 'U':
+		w.acgt += delta
+	}
+}
+
+// windowAt returns the window of up to c.window bases centered on position i
+func (c *Colorer) windowAt(i, length int) (start, end int) {
+	window := max(c.window, 1)
+	start = max(i-window/2, 0)
+	end = min(start+window, length)
+	start = max(end-window, 0)
+	return start, end
+}
+
+// newWindowCounts returns the sliding window of the GC and CpG modes, or nil in the
+// nucleotide mode
+func (c *Colorer) newWindowCounts(upper string) *windowCounts {
+	if c.mode == ModeNucleotide {
+		return nil
+	}
+	return &windowCounts{upper: upper}
+}
+
+// modeColor returns the color of the base at position i in the GC or CpG mode. Positions
+// must be requested in increasing order.
+func (c *Colorer) modeColor(w *windowCounts, i int) string {
+	w.moveTo(c.windowAt(i, len(w.upper)))
+
+	if c.mode == ModeGC {
+		if w.acgt == 0 {
+			return c.scheme.N
+		}
+		return c.heatColor(float64(w.gc) / float64(w.acgt))
+	}
+
+	color := cpgOtherCode
+	if isCpG(w.upper, i) || (i > 0 && isCpG(w.upper, i-1)) {
+		color = cpgCode
+	}
+	if w.isCpGIsland() {
+		color += cpgIslandCode
+	}
+	return color
+}
+
+// isCpGIsland applies the Gardiner-Garden and Frommer criteria to the window: GC fraction
+// above 0.5 and an observed/expected CpG ratio above 0.6
+func (w *windowCounts) isCpGIsland() bool {
+	if w.acgt == 0 || w.c == 0 || w.g == 0 {
+		return false
+	}
+	gc := float64(w.gc) / float64(w.acgt)
+	ratio := float64(w.cpgs) * float64(w.acgt) / (float64(w.c) * float64(w.g))
+	return gc > cpgIslandGC && ratio > cpgIslandRatio
+}
@@ -0,0 +1,48 @@
+package colorer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWindowCountsMatchDirectCounts(t *testing.T) {
+	sequence := "ATCGCGNNACGTTCGCGCGAUCG-GCCGAT"
+	for _, window := range []int{1, 2, 5, 10, 50} {
+		c := &Colorer{mode: ModeCpG, window: window}
+		w := c.newWindowCounts(sequence)
+
+		for i := range sequence {
+			start, end := c.windowAt(i, len(sequence))
+			w.moveTo(start, end)
+
+			text := sequence[start:end]
+			gc := strings.Count(text, "G") + strings.Count(text, "C")
+			acgt := gc + strings.Count(text, "A") + strings.Count(text, "T") + strings.Count(text, "U")
+			cpgs := strings.Count(text, "CG")
+			if w.gc != gc || w.acgt != acgt || w.cpgs != cpgs {
+				t.Fatalf("window %d at %d: got gc=%d acgt=%d cpg=%d, want gc=%d acgt=%d cpg=%d",
+					window, i, w.gc, w.acgt, w.cpgs, gc, acgt, cpgs)
+			}
+		}
+	}
+}
+
+func TestWindowAt(t *testing.T) {
+	c := &Colorer{window: 10}
+	tests := []struct {
+		i, length  int
+		start, end int
+	}{
+		{0, 100, 0, 10},
+		{50, 100, 45, 55},
+		{99, 100, 90, 100},
+		{3, 6, 0, 6},
+	}
+
+	for _, tt := range tests {
+		start, end := c.windowAt(tt.i, tt.length)
+		if start != tt.start || end != tt.end {
+			t.Errorf("windowAt(%d, %d) = [%d, %d), want [%d, %d)", tt.i, tt.length, start, end, tt.start, tt.end)
+		}
+	}
+}
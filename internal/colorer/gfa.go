@@ -0,0 +1,112 @@
+package colorer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/benekenobi/colordna/internal/parser"
+)
+
+// gfaRecordCodes maps GFA record type letters to their colors
+var gfaRecordCodes = map[byte]string{
+	'H': "\033[1m\033[97m", // Bold bright white - header
+	'S': "\033[1m\033[94m", // Bold bright blue - segment
+	'L': "\033[1m\033[92m", // Bold bright green - link
+	'E': "\033[1m\033[92m", // Bold bright green - GFA2 edge
+	'C': "\033[1m\033[96m", // Bold bright cyan - containment
+	'P': "\033[1m\033[95m", // Bold bright magenta - path
+	'W': "\033[1m\033[95m", // Bold bright magenta - walk
+	'O': "\033[1m\033[95m", // Bold bright magenta - GFA2 ordered group
+	'U': "\033[1m\033[95m", // Bold bright magenta - GFA2 unordered group
+	'F': "\033[1m\033[93m", // Bold bright yellow - GFA2 fragment
+	'G': "\033[1m\033[90m", // Bold dark gray - GFA2 gap
+}
+
+// SetMaxSegmentLength sets the number of bases of a GFA segment shown before it is
+// truncated, 0 shows whole segments
+func (c *Colorer) SetMaxSegmentLength(n int) {
+	c.maxSegment = n
+}
+
+// ColorizeGFA colorizes a GFA1 or GFA2 record: the record type, segment sequences,
+// link orientations and overlap CIGARs, path steps and optional tags
+func (c *Colorer) ColorizeGFA(line string) string {
+	fields := strings.Split(line, "\t")
+	if len(fields) < 2 || len(fields[0]) != 1 {
+		return line
+	}
+	recordType := fields[0][0]
+
+	tagsFrom := len(fields)
+	switch recordType {
+	case 'S':
+		seqIndex := 2
+		if parser.IsGFA2Segment(fields) {
+			seqIndex = 3
+		}
+		if seqIndex < len(fields) {
+			fields[1] = seqidCode + fields[1] + resetCode
+			fields[seqIndex] = c.colorizeSegment(fields[seqIndex])
+			tagsFrom = seqIndex + 1
+		}
+	case 'L', 'C':
+		// From, orientation, to, orientation, overlap (and position for containments)
+		if len(fields) >= 6 {
+			fields[2] = colorizeStrand(fields[2])
+			fields[4] = colorizeStrand(fields[4])
+			overlap := 5
+			if recordType == 'C' && len(fields) >= 7 {
+				overlap = 6
+			}
+			fields[overlap] = colorizeCIGAR(fields[overlap])
+			tagsFrom = overlap + 1
+		}
+	case 'P':
+		// Path name, oriented segment names and overlaps
+		if len(fields) >= 4 {
+			fields[1] = seqidCode + fields[1] + resetCode
+			fields[2] = colorizePathSteps(fields[2])
+			overlaps := strings.Split(fields[3], ",")
+			for i, overlap := range overlaps {
+				overlaps[i] = colorizeCIGAR(overlap)
+			}
+			fields[3] = strings.Join(overlaps, ",")
+			tagsFrom = 4
+		}
+	case 'H':
+		tagsFrom = 1
+	}
+
+	for i := tagsFrom; i < len(fields); i++ {
+		fields[i] = c.colorizeSAMTag(fields[i])
+	}
+	if code, ok := gfaRecordCodes[recordType]; ok {
+		fields[0] = code + fields[0] + resetCode
+	}
+
+	return strings.Join(fields, "\t")
+}
+
+// colorizeSegment colors a segment sequence, truncating it to the maximum length
+func (c *Colorer) colorizeSegment(sequence string) string {
+	if sequence == "*" {
+		return sequence
+	}
+	if c.maxSegment > 0 && len(sequence) > c.maxSegment {
+		omitted := len(sequence) - c.maxSegment
+		return c.ColorizeSequence(sequence[:c.maxSegment]) +
+			fmt.Sprintf("%s…(+%d bp)%s", tagTypeCode, omitted, resetCode)
+	}
+	return c.ColorizeSequence(sequence)
+}
+
+// colorizePathSteps colors the orientation of each step of a path, e.g. "1+,2-,3+"
+func colorizePathSteps(steps string) string {
+	parts := strings.Split(steps, ",")
+	for i, step := range parts {
+		if n := len(step); n > 1 && (step[n-1] == '+' || step[n-1] == '-') {
+			parts[i] = step[:n-1] + colorizeStrand(step[n-1:])
+		}
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,105 @@
+package colorer
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	seqidCode     = "\033[1m"         // Bold sequence IDs
+	sourceCode    = "\033[90m"        // Dark gray sources and phases
+	plusCode      = "\033[92m"        // Bright green plus strand
+	minusCode     = "\033[91m"        // Bright red minus strand
+	attrKeyCode   = "\033[36m"        // Cyan attribute keys
+	attrIDKeyCode = "\033[1m\033[36m" // Bold cyan keys linking features
+)
+
+// featureTypeCodes maps GFF feature types to their colors
+var featureTypeCodes = map[string]string{
+	"gene":            "\033[1m\033[94m", // Bold bright blue
+	"pseudogene":      "\033[94m",        // Bright blue
+	"mrna":            "\033[95m",        // Bright magenta
+	"transcript":      "\033[95m",
+	"ncrna":           "\033[35m", // Magenta
+	"lnc_rna":         "\033[35m",
+	"trna":            "\033[35m",
+	"rrna":            "\033[35m",
+	"exon":            "\033[92m", // Bright green
+	"cds":             "\033[93m", // Bright yellow
+	"five_prime_utr":  "\033[96m", // Bright cyan
+	"three_prime_utr": "\033[96m",
+	"utr":             "\033[96m",
+	"5utr":            "\033[96m",
+	"3utr":            "\033[96m",
+	"start_codon":     "\033[91m", // Bright red
+	"stop_codon":      "\033[91m",
+}
+
+// idAttributes are attribute keys that link features together and are emphasized
+var idAttributes = map[string]bool{
+	"ID": true, "Parent": true, "Name": true,
+	"gene_id": true, "transcript_id": true, "gene_name": true,
+}
+
+// ColorizeGFF colorizes a GFF3 or GTF feature line
+func (c *Colorer) ColorizeGFF(line string) string {
+	fields := strings.Split(line, "\t")
+	if len(fields) != 9 {
+		return line // Not a feature line
+	}
+
+	fields[0] = seqidCode + fields[0] + resetCode
+	fields[1] = sourceCode + fields[1] + resetCode
+	if code, ok := featureTypeCodes[strings.ToLower(fields[2])]; ok {
+		fields[2] = code + fields[2] + resetCode
+	}
+	if score, err := strconv.ParseFloat(fields[5], 64); err == nil {
+		if color := c.getQualityColor(int(score)); color != "" {
+			fields[5] = color + fields[5] + resetCode
+		}
+	}
+	fields[6] = colorizeStrand(fields[6])
+	fields[7] = sourceCode + fields[7] + resetCode
+	fields[8] = colorizeAttributes(fields[8])
+
+	return strings.Join(fields, "\t")
+}
+
+// colorizeStrand colors a '+' or '-' strand value
+func colorizeStrand(strand string) string {
+	switch strand {
+	case "+":
+		return plusCode + strand + resetCode
+	case "-":
+		return minusCode + strand + resetCode
+	default:
+		return sourceCode + strand + resetCode
+	}
+}
+
+// colorizeAttributes colors the keys of GFF3 (key=value;...) and GTF (key "value"; ...)
+// attributes, emphasizing the keys that link features together
+func colorizeAttributes(attributes string) string {
+	if attributes == "." {
+		return attributes
+	}
+
+	pairs := strings.Split(attributes, ";")
+	for i, pair := range pairs {
+		trimmed := strings.TrimLeft(pair, " ")
+		indent := pair[:len(pair)-len(trimmed)]
+
+		// GFF3 uses '=', GTF a space between key and value
+		sepIndex := strings.IndexAny(trimmed, "= ")
+		if sepIndex <= 0 {
+			continue
+		}
+		key := trimmed[:sepIndex]
+		code := attrKeyCode
+		if idAttributes[key] {
+			code = attrIDKeyCode
+		}
+		pairs[i] = indent + code + key + resetCode + trimmed[sepIndex:]
+	}
+	return strings.Join(pairs, ";")
+}
@@ -0,0 +1,98 @@
+package colorer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultGroupSeparator = " "  // Between groups of bases when the scheme sets none
+	defaultGroupGap       = "  " // Between blocks of groups when the scheme sets none
+)
+
+// coordinateWidth is the width of the left-margin coordinates, as in GenBank ORIGIN lines
+const coordinateWidth = 9
+
+// rulerCode is the style of position rulers and margin coordinates
+const rulerCode = "\033[2m\033[36m" // Dim cyan
+
+// CoordinateMargin returns the left-margin coordinate for a line starting at position
+func (c *Colorer) CoordinateMargin(position int) string {
+	return fmt.Sprintf("%s%*d%s ", rulerCode, coordinateWidth, position, resetCode)
+}
+
+// BlankMargin returns padding as wide as CoordinateMargin, for lines without coordinates
+func (c *Colorer) BlankMargin() string {
+	return strings.Repeat(" ", coordinateWidth+1)
+}
+
+// Ruler returns a position scale for sequence lines of the given width, with a label
+// ending at every tenth base and room left for group separators
+func (c *Colorer) Ruler(width int) string {
+	// Display column just after each base, once separators are inserted
+	ends := make([]int, width+1)
+	for i := 0; i < width; i++ {
+		ends[i+1] = ends[i] + visibleWidth(c.groupSeparator(i)) + 1
+	}
+
+	scale := []byte(strings.Repeat(" ", ends[width]))
+	if width > 0 {
+		scale[ends[1]-1] = '1'
+	}
+	next := 1 // First display column free for a label
+	for column := 10; column <= width; column += 10 {
+		label := strconv.Itoa(column)
+		start := ends[column] - len(label)
+		if start <= next {
+			continue
+		}
+		copy(scale[start:], label)
+		next = ends[column]
+	}
+	return rulerCode + string(scale) + resetCode
+}
+
+// visibleWidth returns the number of terminal columns of text, ignoring escape codes
+func visibleWidth(text string) int {
+	width := 0
+	inEscape := false
+	for _, r := range text {
+		switch {
+		case r == '\033':
+			inEscape = true
+		case inEscape:
+			if r == 'm' {
+				inEscape = false
+			}
+		default:
+			width++
+		}
+	}
+	return width
+}
+
+// SetGrouping sets the number of bases per separated group and per larger block, as in
+// GenBank ORIGIN lines. Grouping applies to SequenceLines and, so that scores stay
+// under their bases, ColorizeGroupedQuality; record fields such as SAM SEQ are not grouped.
+func (c *Colorer) SetGrouping(size, gap int) {
+	c.groupSize = size
+	c.groupGap = gap
+}
+
+// groupSeparator returns the text written before the base at index i
+func (c *Colorer) groupSeparator(i int) string {
+	if c.groupSize <= 0 || i == 0 || i%c.groupSize != 0 {
+		return ""
+	}
+	if c.groupGap > 0 && i%c.groupGap == 0 {
+		if c.scheme.GroupGap != "" {
+			return c.scheme.GroupGap
+		}
+		return defaultGroupGap
+	}
+	if c.scheme.GroupSeparator != "" {
+		return c.scheme.GroupSeparator
+	}
+	return defaultGroupSeparator
+}
@@ -0,0 +1,107 @@
+package colorer
+
+import (
+	"strings"
+
+	"github.com/benekenobi/colordna/internal/parser"
+)
+
+const (
+	motifCode     = "\033[7m"          // Reverse video for highlighted motifs
+	cutMarkerCode = "\033[1m\033[96m|" // Bold bright cyan cut-site marker
+)
+
+// Motif is a sequence pattern to highlight, such as an adapter or a restriction site
+type Motif struct {
+	Name     string
+	Sequence string // Pattern using IUPAC nucleotide codes
+	Cut      int    // Cut offset within Sequence, or -1 if there is no cut site
+}
+
+// iupacBases maps IUPAC codes to the nucleotides they stand for
+var iupacBases = map[byte]string{
+	'A': "A", 'C': "C", 'G': "G", 'T': "TU", 'U': "TU",
+	'R': "AG", 'Y': "CTU", 'S': "CG", 'W': "ATU", 'K': "GTU", 'M': "AC",
+	'B': "CGTU", 'D': "AGTU", 'H': "ACTU", 'V': "ACG", 'N': "ACGTU",
+}
+
+// NewEnzymeMotif builds a Motif from a REBASE-style site such as "G^AATTC"
+func NewEnzymeMotif(name, site string) Motif {
+	cut := strings.Index(site, "^")
+	return Motif{
+		Name:     name,
+		Sequence: strings.ToUpper(strings.Replace(site, "^", "", 1)),
+		Cut:      cut,
+	}
+}
+
+// NewAdapterMotif builds a Motif without a cut site
+func NewAdapterMotif(name, sequence string) Motif {
+	return Motif{Name: name, Sequence: strings.ToUpper(sequence), Cut: -1}
+}
+
+// SetMotifs sets the motifs highlighted by ColorizeSequence
+func (c *Colorer) SetMotifs(motifs []Motif) {
+	c.motifs = motifs
+}
+
+// findMotifs marks the positions covered by motifs and the positions preceded by a cut site.
+// Both strands are searched; cuts has one more entry than the sequence for a trailing cut.
+func (c *Colorer) findMotifs(upper string) (covered []bool, cuts []bool) {
+	if len(c.motifs) == 0 {
+		return nil, nil
+	}
+
+	covered = make([]bool, len(upper))
+	cuts = make([]bool, len(upper)+1)
+	for _, motif := range c.motifs {
+		c.markMotif(upper, motif.Sequence, motif.Cut, covered, cuts)
+
+		revcomp := parser.ReverseComplement(motif.Sequence)
+		if revcomp != motif.Sequence {
+			cut := motif.Cut
+			if cut >= 0 {
+				cut = len(motif.Sequence) - cut
+			}
+			c.markMotif(upper, revcomp, cut, covered, cuts)
+		}
+	}
+
+	return covered, cuts
+}
+
+// markMotif marks every (possibly overlapping) occurrence of pattern in upper
+func (c *Colorer) markMotif(upper, pattern string, cut int, covered, cuts []bool) {
+	if len(pattern) == 0 || len(pattern) > len(upper) {
+		return
+	}
+
+	for start := 0; start+len(pattern) <= len(upper); start++ {
+		if !matchesIUPAC(upper[start:start+len(pattern)], pattern) {
+			continue
+		}
+		for i := start; i < start+len(pattern); i++ {
+			covered[i] = true
+		}
+		if cut >= 0 {
+			cuts[start+cut] = true
+		}
+	}
+}
+
+// matchesIUPAC reports whether sequence matches an IUPAC pattern of the same length
+func matchesIUPAC(sequence, pattern string) bool {
+	for i := 0; i < len(pattern); i++ {
+		bases, ok := iupacBases[pattern[i]]
+		if !ok {
+			if sequence[i] != pattern[i] {
+				return false
+			}
+			continue
+		}
+		if !strings.ContainsRune(bases, rune(sequence[i])) {
+			return false
+		}
+	}
+	return true
+}
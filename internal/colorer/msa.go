@@ -0,0 +1,335 @@
+package colorer
+
+import (
+	"math"
+	"strings"
+
+	"github.com/benekenobi/colordna/internal/parser"
+)
+
+const (
+	gapCode        = "\033[90m" // Dark gray for alignment gaps
+	annotationCode = "\033[36m" // Cyan for Stockholm annotations such as SS_cons
+)
+
+// proteinCodes colors amino acids by physicochemical class, similar to Clustal X
+var proteinCodes = map[byte]string{
+	'A': "\033[94m", 'I': "\033[94m", 'L': "\033[94m", 'M': "\033[94m", // Hydrophobic - bright blue
+	'F': "\033[94m", 'W': "\033[94m", 'V': "\033[94m",
+	'K': "\033[91m", 'R': "\033[91m", // Positive charge - bright red
+	'D': "\033[95m", 'E': "\033[95m", // Negative charge - bright magenta
+	'N': "\033[92m", 'Q': "\033[92m", 'S': "\033[92m", 'T': "\033[92m", // Polar - bright green
+	'C': "\033[35m",                  // Cysteine - magenta
+	'G': "\033[33m",                  // Glycine - yellow
+	'P': "\033[93m",                  // Proline - bright yellow
+	'H': "\033[96m", 'Y': "\033[96m", // Aromatic - bright cyan
+}
+
+// Alphabet selects nucleotide or amino acid colors for alignment residues
+type Alphabet int
+
+const (
+	AlphabetAuto       Alphabet = iota // Detected from each block of rows
+	AlphabetNucleotide                 // Scheme nucleotide colors
+	AlphabetProtein                    // Amino acid colors by residue class
+)
+
+// DetectAlignmentAlphabet detects the alphabet of gapped FASTA lines, ignoring headers
+func DetectAlignmentAlphabet(lines []string) Alphabet {
+	var sequences []string
+	for _, line := range lines {
+		if !strings.HasPrefix(line, ">") {
+			sequences = append(sequences, strings.ReplaceAll(line, " ", ""))
+		}
+	}
+	if isProteinAlignment(sequences) {
+		return AlphabetProtein
+	}
+	return AlphabetNucleotide
+}
+
+// SetAlignmentAlphabet fixes the residue colors of alignments, so that rows colored
+// separately share one palette. AlphabetAuto detects it from each block.
+func (c *Colorer) SetAlignmentAlphabet(alphabet Alphabet) {
+	c.alphabet = alphabet
+}
+
+// isProtein reports whether alignment sequences use amino acid colors
+func (c *Colorer) isProtein(sequences []string) bool {
+	switch c.alphabet {
+	case AlphabetNucleotide:
+		return false
+	case AlphabetProtein:
+		return true
+	default:
+		return isProteinAlignment(sequences)
+	}
+}
+
+// SetConservation enables coloring only the alignment residues that match their
+// column consensus
+func (c *Colorer) SetConservation(enabled bool) {
+	c.conservation = enabled
+}
+
+// ColorizeAlignment colorizes the residues of a block of alignment rows. Gaps are
+// grayed out and residues use nucleotide or amino acid colors depending on the block.
+func (c *Colorer) ColorizeAlignment(rows []string) []string {
+	records := make([][]string, len(rows))
+	for i, row := range rows {
+		records[i] = []string{row}
+	}
+
+	colored := make([]string, len(rows))
+	for i, record := range c.ColorizeAlignmentRecords(records) {
+		colored[i] = record[0]
+	}
+	return colored
+}
+
+// ColorizeAlignmentRecords colorizes aligned sequences that are each split over
+// several lines, such as the records of an aligned FASTA file
+func (c *Colorer) ColorizeAlignmentRecords(records [][]string) [][]string {
+	sequences := make([]string, len(records))
+	for i, lines := range records {
+		sequences[i] = strings.ReplaceAll(strings.Join(lines, ""), " ", "")
+	}
+	protein := c.isProtein(sequences)
+
+	consensus := ""
+	if c.conservation {
+		consensus = AlignmentConsensus(sequences)
+	}
+
+	colored := make([][]string, len(records))
+	for i, lines := range records {
+		offset := 0
+		colored[i] = make([]string, len(lines))
+		for j, line := range lines {
+			colored[i][j] = c.colorizeResidues(line, consensus, offset, protein)
+			offset += len(line) - strings.Count(line, " ")
+		}
+	}
+	return colored
+}
+
+// ColorizeAnnotation colorizes a Stockholm annotation row. Consensus sequences are
+// colored as residues, other annotations such as secondary structure in one color.
+func (c *Colorer) ColorizeAnnotation(residues string) string {
+	for i := 0; i < len(residues); i++ {
+		char := residues[i]
+		if !parser.IsGap(char) && !(char >= 'A' && char <= 'Z') && !(char >= 'a' && char <= 'z') {
+			return annotationCode + residues + resetCode
+		}
+	}
+	return c.colorizeResidues(residues, "", 0, c.isProtein([]string{residues}))
+}
+
+// AlignmentConsensus returns the most frequent residue of each alignment column, in
+// uppercase and ignoring gaps. Columns without residues are '-'.
+func AlignmentConsensus(sequences []string) string {
+	stats := AlignmentColumnStats(sequences)
+	consensus := make([]byte, len(stats))
+	for i, column := range stats {
+		consensus[i] = column.Consensus
+	}
+	return string(consensus)
+}
+
+// ColumnStats summarizes an alignment column
+type ColumnStats struct {
+	Consensus byte    // Most frequent residue in uppercase, '-' if the column has only gaps
+	Identity  float64 // Fraction of the sequences carrying the consensus residue
+	Entropy   float64 // Shannon entropy of the residues in bits, ignoring gaps
+}
+
+// AlignmentColumnStats computes the consensus, identity and entropy of each column of
+// aligned sequences, which must not contain spaces
+func AlignmentColumnStats(sequences []string) []ColumnStats {
+	length := 0
+	for _, sequence := range sequences {
+		if len(sequence) > length {
+			length = len(sequence)
+		}
+	}
+
+	stats := make([]ColumnStats, length)
+	var counts [256]int
+	for col := 0; col < length; col++ {
+		counts = [256]int{}
+		residues := 0
+		best, bestCount := byte('-'), 0
+		for _, sequence := range sequences {
+			if col >= len(sequence) || parser.IsGap(sequence[col]) {
+				continue
+			}
+			upper := toUpperByte(sequence[col])
+			counts[upper]++
+			residues++
+			if counts[upper] > bestCount {
+				best, bestCount = upper, counts[upper]
+			}
+		}
+
+		entropy := 0.0
+		for _, count := range counts {
+			if count > 0 {
+				p := float64(count) / float64(residues)
+				entropy -= p * math.Log2(p)
+			}
+		}
+		stats[col] = ColumnStats{
+			Consensus: best,
+			Identity:  float64(bestCount) / float64(len(sequences)),
+			Entropy:   entropy,
+		}
+	}
+	return stats
+}
+
+// colorizeResidues colorizes one line of aligned residues starting at column offset.
+// With a consensus, residues that differ from their column consensus are left plain.
+func (c *Colorer) colorizeResidues(residues, consensus string, offset int, protein bool) string {
+	var result strings.Builder
+	result.Grow(len(residues) * 10)
+
+	col := offset
+	for i := 0; i < len(residues); i++ {
+		char := residues[i]
+		switch {
+		case char == ' ':
+			result.WriteByte(char)
+			continue
+		case parser.IsGap(char):
+			result.WriteString(gapCode)
+			result.WriteByte(char)
+			result.WriteString(resetCode)
+			col++
+			continue
+		}
+
+		upper := toUpperByte(char)
+		matches := consensus == "" || (col < len(consensus) && consensus[col] == upper)
+		col++
+
+		color := ""
+		if matches {
+			if protein {
+				color = proteinCodes[upper]
+			} else {
+				color = c.getColorForNucleotide(rune(upper))
+			}
+		}
+		if color != "" {
+			result.WriteString(color)
+			result.WriteByte(char)
+			result.WriteString(resetCode)
+		} else {
+			result.WriteByte(char)
+		}
+	}
+
+	return result.String()
+}
+
+// isProteinAlignment reports whether aligned sequences contain residues other than
+// nucleotides
+func isProteinAlignment(sequences []string) bool {
+	for _, sequence := range sequences {
+		for i := 0; i < len(sequence); i++ {
+			if parser.IsGap(sequence[i]) {
+				continue
+			}
+			switch toUpperByte(sequence[i]) {
+			case 'A', 'C', 'G', 'T', 'U', 'N':
+			default:
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// toUpperByte converts an ASCII letter to uppercase
+func toUpperByte(char byte) byte {
+	if char >= 'a' && char <= 'z' {
+		return char - 'a' + 'A'
+	}
+	return char
+}
+
+// AlignmentTrack is a line of per-column symbols drawn beneath an alignment
+type AlignmentTrack struct {
+	Name    string
+	symbols []string // Colored symbol of each alignment column
+}
+
+// SetTrackThreshold sets the identity at or above which a column's consensus residue
+// is highlighted in the consensus track
+func (c *Colorer) SetTrackThreshold(threshold float64) {
+	c.trackThreshold = threshold
+}
+
+// AlignmentTracks computes the consensus, identity and entropy tracks of aligned
+// sequences, which must not contain spaces. Identity and entropy are drawn as bars
+// colored by the quality gradient, with well-conserved columns at the top.
+func (c *Colorer) AlignmentTracks(sequences []string) []AlignmentTrack {
+	stats := AlignmentColumnStats(sequences)
+	protein := c.isProtein(sequences)
+
+	// Highest possible entropy for the alphabet
+	maxEntropy := math.Log2(4)
+	if protein {
+		maxEntropy = math.Log2(20)
+	}
+
+	consensus := AlignmentTrack{Name: "consensus", symbols: make([]string, len(stats))}
+	identity := AlignmentTrack{Name: "identity", symbols: make([]string, len(stats))}
+	entropy := AlignmentTrack{Name: "entropy", symbols: make([]string, len(stats))}
+	for i, column := range stats {
+		switch {
+		case column.Consensus == '-':
+			consensus.symbols[i] = gapCode + "-" + resetCode
+		case column.Identity >= c.trackThreshold:
+			consensus.symbols[i] = c.colorizeResidues(string(column.Consensus), "", 0, protein)
+		default:
+			consensus.symbols[i] = dimCode + strings.ToLower(string(column.Consensus)) + resetCode
+		}
+
+		identity.symbols[i] = c.heatColor(column.Identity) + barGlyph(column.Identity) + resetCode
+
+		normalized := math.Min(column.Entropy/maxEntropy, 1)
+		entropy.symbols[i] = c.heatColor(1-normalized) + barGlyph(normalized) + resetCode
+	}
+
+	return []AlignmentTrack{consensus, identity, entropy}
+}
+
+// Layout renders the track columns starting at offset, copying the spaces of layout,
+// a line of residues the track is drawn beneath
+func (t AlignmentTrack) Layout(layout string, offset int) string {
+	var result strings.Builder
+	col := offset
+	for i := 0; i < len(layout); i++ {
+		if layout[i] == ' ' {
+			result.WriteByte(' ')
+			continue
+		}
+		if col < len(t.symbols) {
+			result.WriteString(t.symbols[col])
+		}
+		col++
+	}
+	return result.String()
+}
+
+// barGlyph returns the block glyph for a fraction between 0 and 1
+func barGlyph(fraction float64) string {
+	level := int(fraction * float64(len(qualityBarGlyphs)))
+	if level < 0 {
+		level = 0
+	} else if level >= len(qualityBarGlyphs) {
+		level = len(qualityBarGlyphs) - 1
+	}
+	return string(qualityBarGlyphs[level])
+}
@@ -0,0 +1,24 @@
+package colorer
+
+import "testing"
+
+func TestDetectAlignmentAlphabet(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  Alphabet
+	}{
+		{"nucleotide", []string{">a", "ACGT-N", ">b", "acg-tu"}, AlphabetNucleotide},
+		{"protein in a later row", []string{">a", "AC--GTA", ">b", "MKV-LLA"}, AlphabetProtein},
+		{"headers ignored", []string{">MKVLL protein", "ACGT"}, AlphabetNucleotide},
+		{"gaps only", []string{">a", "----"}, AlphabetNucleotide},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectAlignmentAlphabet(tt.lines); got != tt.want {
+				t.Errorf("DetectAlignmentAlphabet(%q) = %d, want %d", tt.lines, got, tt.want)
+			}
+		})
+	}
+}
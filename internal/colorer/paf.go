@@ -0,0 +1,145 @@
+package colorer
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/benekenobi/colordna/internal/parser"
+)
+
+const (
+	csMatchCode     = "\033[2m"  // Dim for cs match lengths
+	csInsertionCode = "\033[95m" // Bright magenta for cs insertions
+	csDeletionCode  = "\033[91m" // Bright red for cs deletions
+	csIntronCode    = "\033[90m" // Dark gray for cs introns
+)
+
+// ColorizePAF colorizes a PAF record: strand, identity of the matching bases, mapping
+// quality and the optional tags, with cs and cg decoded into colored differences
+func (c *Colorer) ColorizePAF(line string) string {
+	fields := strings.Split(line, "\t")
+	if len(fields) < 12 {
+		return line
+	}
+
+	fields[0] = seqidCode + fields[0] + resetCode
+	fields[4] = colorizeStrand(fields[4])
+	fields[5] = seqidCode + fields[5] + resetCode
+
+	// Fields 9 and 10 (indices 9 and 10) hold the matching bases and the alignment length
+	matches, errMatches := strconv.Atoi(fields[9])
+	length, errLength := strconv.Atoi(fields[10])
+	if errMatches == nil && errLength == nil && length > 0 {
+		code := c.heatColor(float64(matches) / float64(length))
+		fields[9] = code + fields[9] + resetCode
+		fields[10] = code + fields[10] + resetCode
+	}
+
+	// Field 11 (index 11) contains the mapping quality, 255 means unavailable
+	if mapq, err := strconv.Atoi(fields[11]); err == nil && mapq != 255 {
+		if color := c.getQualityColor(mapq); color != "" {
+			fields[11] = color + fields[11] + resetCode
+		}
+	}
+
+	for i := 12; i < len(fields); i++ {
+		switch {
+		case strings.HasPrefix(fields[i], "cs:Z:"):
+			fields[i] = tagNameCode + "cs" + resetCode + ":" + tagTypeCode + "Z" + resetCode + ":" +
+				c.colorizeCS(strings.TrimPrefix(fields[i], "cs:Z:"))
+		case strings.HasPrefix(fields[i], "cg:Z:"):
+			fields[i] = tagNameCode + "cg" + resetCode + ":" + tagTypeCode + "Z" + resetCode + ":" +
+				colorizeCIGAR(strings.TrimPrefix(fields[i], "cg:Z:"))
+		default:
+			fields[i] = c.colorizeSAMTag(fields[i])
+		}
+	}
+
+	return strings.Join(fields, "\t")
+}
+
+// colorizeCS colors a minimap2 cs difference string: ":N" identical runs, "=ACGT"
+// identical bases, "*ag" substitutions, "+ac" insertions, "-ct" deletions and
+// "~gt10ag" introns
+func (c *Colorer) colorizeCS(cs string) string {
+	var result strings.Builder
+	for i := 0; i < len(cs); {
+		op := cs[i]
+		end := i + 1
+		for end < len(cs) && !strings.ContainsRune(":=*+-~", rune(cs[end])) {
+			end++
+		}
+		value := cs[i+1 : end]
+
+		switch op {
+		case ':':
+			result.WriteString(csMatchCode + ":" + value + resetCode)
+		case '=':
+			result.WriteString("=" + c.colorizeCSBases(value, ""))
+		case '*':
+			// Reference base followed by query base, the query base highlighted
+			result.WriteString("*" + value[:len(value)/2])
+			result.WriteString(c.colorizeCSBases(value[len(value)/2:], motifCode))
+		case '+':
+			result.WriteString(csInsertionCode + "+" + value + resetCode)
+		case '-':
+			result.WriteString(csDeletionCode + "-" + value + resetCode)
+		case '~':
+			result.WriteString(csIntronCode + "~" + value + resetCode)
+		default:
+			result.WriteString(cs[i:end])
+		}
+		i = end
+	}
+	return result.String()
+}
+
+// colorizeCSBases colors cs bases by nucleotide, keeping their case, which is significant
+// in cs strings
+func (c *Colorer) colorizeCSBases(bases, highlight string) string {
+	var result strings.Builder
+	for i := 0; i < len(bases); i++ {
+		code := c.getColorForNucleotide(rune(toUpperByte(bases[i]))) + highlight
+		if code == "" {
+			result.WriteByte(bases[i])
+			continue
+		}
+		result.WriteString(code)
+		result.WriteByte(bases[i])
+		result.WriteString(resetCode)
+	}
+	return result.String()
+}
+
+// ColorizeMAFLine colorizes a MAF line. The text of "s" lines is colored by nucleotide,
+// with bases that differ from the reference text (the first "s" line of the block)
+// highlighted. Other lines have their line type bolded.
+func (c *Colorer) ColorizeMAFLine(line, reference string) string {
+	text, offset, ok := parser.MAFSequenceText(line)
+	if !ok {
+		if line == "" || strings.HasPrefix(line, "#") {
+			return line
+		}
+		return c.ColorizeKeywordLine(line)
+	}
+
+	var result strings.Builder
+	result.WriteString(keywordCode + "s" + resetCode + line[1:offset])
+	for i := 0; i < len(text); i++ {
+		char := text[i]
+		if parser.IsGap(char) {
+			result.WriteString(gapCode + string(char) + resetCode)
+			continue
+		}
+
+		code := c.getColorForNucleotide(rune(toUpperByte(char)))
+		if i < len(reference) && !parser.IsGap(reference[i]) &&
+			toUpperByte(reference[i]) != toUpperByte(char) {
+			code += motifCode
+		}
+		result.WriteString(code + string(char) + resetCode)
+	}
+	result.WriteString(line[offset+len(text):])
+
+	return result.String()
+}
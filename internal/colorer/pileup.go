@@ -0,0 +1,85 @@
+package colorer
+
+import (
+	"strings"
+)
+
+const (
+	forwardMatchCode = "\033[92m"        // Bright green for '.' matches on the forward strand
+	reverseMatchCode = "\033[32m"        // Green for ',' matches on the reverse strand
+	readBoundaryCode = "\033[1m\033[96m" // Bold bright cyan for '^' and '$' read markers
+	placeholderCode  = "\033[90m"        // Dark gray for '*', '#' and '>'/'<' placeholders
+)
+
+// ColorizePileup colorizes a samtools mpileup record, coloring the read bases and
+// qualities of each sample
+func (c *Colorer) ColorizePileup(line string) string {
+	fields := strings.Split(line, "\t")
+	if len(fields) < 6 {
+		return line
+	}
+
+	fields[0] = seqidCode + fields[0] + resetCode
+	fields[2] = c.ColorizeSequence(fields[2])
+
+	// Each sample has depth, read bases and qualities columns
+	for i := 3; i+2 < len(fields); i += 3 {
+		fields[i+1] = c.colorizeReadBases(fields[i+1])
+		if fields[i+2] != "*" {
+			fields[i+2] = c.ColorizeQuality(fields[i+2])
+		}
+	}
+
+	return strings.Join(fields, "\t")
+}
+
+// colorizeReadBases colors a pileup read-base column: matches by strand, mismatches by
+// nucleotide, read start and end markers, and indels such as "+2AG" or "-1T"
+func (c *Colorer) colorizeReadBases(bases string) string {
+	var result strings.Builder
+	result.Grow(len(bases) * 10)
+
+	for i := 0; i < len(bases); i++ {
+		char := bases[i]
+		switch {
+		case char == '.':
+			result.WriteString(forwardMatchCode + "." + resetCode)
+		case char == ',':
+			result.WriteString(reverseMatchCode + "," + resetCode)
+		case char == '^' && i+1 < len(bases):
+			// Read start, followed by the read's mapping quality as a character
+			result.WriteString(readBoundaryCode + "^" + resetCode)
+			result.WriteString(placeholderCode + bases[i+1:i+2] + resetCode)
+			i++
+		case char == '$':
+			result.WriteString(readBoundaryCode + "$" + resetCode)
+		case char == '+' || char == '-':
+			// Indel: sign, length and the inserted or deleted bases
+			end := i + 1
+			for end < len(bases) && bases[end] >= '0' && bases[end] <= '9' {
+				end++
+			}
+			length := 0
+			for _, digit := range bases[i+1 : end] {
+				length = length*10 + int(digit-'0')
+			}
+			if end+length > len(bases) {
+				length = len(bases) - end
+			}
+			code := csInsertionCode
+			if char == '-' {
+				code = csDeletionCode
+			}
+			result.WriteString(code + bases[i:end+length] + resetCode)
+			i = end + length - 1
+		case char == '*' || char == '#' || char == '>' || char == '<':
+			result.WriteString(placeholderCode + string(char) + resetCode)
+		default:
+			// Mismatch, uppercase on the forward and lowercase on the reverse strand
+			color := c.getColorForNucleotide(rune(toUpperByte(char)))
+			result.WriteString(color + string(char) + resetCode)
+		}
+	}
+
+	return result.String()
+}
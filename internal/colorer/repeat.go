@@ -0,0 +1,136 @@
+package colorer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RepeatStyle controls how homopolymer runs and tandem repeats are highlighted
+type RepeatStyle int
+
+const (
+	RepeatUnderline  RepeatStyle = iota // Underlined bases
+	RepeatBackground                    // Gray background behind the bases
+)
+
+const (
+	repeatUnderlineCode  = "\033[4m"        // Underline for repeats
+	repeatBackgroundCode = "\033[48;5;238m" // Dark gray background for repeats
+	repeatNoteCode       = "\033[2m"        // Dim run length annotations
+)
+
+// maxRepeatPeriod is the longest tandem repeat unit that is searched for
+const maxRepeatPeriod = 6
+
+// repeatSpan is a homopolymer run or tandem repeat found in a sequence
+type repeatSpan struct {
+	start, end int // Half-open range of the repeat
+	period     int // Length of the repeated unit, 1 for homopolymers
+}
+
+// ParseRepeatStyle parses a repeat highlight style name: underline or background
+func ParseRepeatStyle(name string) (RepeatStyle, error) {
+	switch strings.ToLower(name) {
+	case "underline", "":
+		return RepeatUnderline, nil
+	case "background":
+		return RepeatBackground, nil
+	default:
+		return RepeatUnderline, fmt.Errorf("unknown repeat style '%s' (use underline or background)", name)
+	}
+}
+
+// SetRepeats sets the minimum lengths of highlighted homopolymer runs and tandem repeats
+// (period 2 to 6), either 0 to disable, the highlight style and whether the length of
+// each repeat is annotated after it
+func (c *Colorer) SetRepeats(homopolymerMin, tandemMin int, style RepeatStyle, annotate bool) {
+	c.homopolymerMin = homopolymerMin
+	c.tandemMin = tandemMin
+	c.repeatStyle = style
+	c.repeatLengths = annotate
+}
+
+// repeatCode returns the style applied to bases inside repeats
+func (c *Colorer) repeatCode() string {
+	if c.repeatStyle == RepeatBackground {
+		return repeatBackgroundCode
+	}
+	return repeatUnderlineCode
+}
+
+// findRepeats marks the positions inside homopolymer runs and tandem repeats, and returns
+// the run length annotations keyed by the position they follow
+func (c *Colorer) findRepeats(upper string) (covered []bool, notes map[int]string) {
+	if c.homopolymerMin <= 0 && c.tandemMin <= 0 {
+		return nil, nil
+	}
+
+	var spans []repeatSpan
+	if c.homopolymerMin > 0 {
+		spans = append(spans, scanRepeats(upper, 1, c.homopolymerMin)...)
+	}
+	if c.tandemMin > 0 {
+		for period := 2; period <= maxRepeatPeriod; period++ {
+			spans = append(spans, scanRepeats(upper, period, c.tandemMin)...)
+		}
+	}
+	if len(spans) == 0 {
+		return nil, nil
+	}
+
+	covered = make([]bool, len(upper))
+	if c.repeatLengths {
+		notes = make(map[int]string)
+	}
+	for _, span := range spans {
+		for i := span.start; i < span.end; i++ {
+			covered[i] = true
+		}
+		if notes == nil {
+			continue
+		}
+		length := span.end - span.start
+		note := fmt.Sprintf("[%d]", length)
+		if span.period > 1 {
+			note = fmt.Sprintf("[%d×%s]", length/span.period, upper[span.start:span.start+span.period])
+		}
+		notes[span.end] += repeatNoteCode + note + resetCode
+	}
+	return covered, notes
+}
+
+// scanRepeats finds the maximal runs of at least two copies of a unit of the given period
+// that are at least minLength bases long. Units that are themselves repeats of a shorter
+// period, or that contain ambiguous bases or gaps, are skipped.
+func scanRepeats(upper string, period, minLength int) []repeatSpan {
+	var spans []repeatSpan
+	minLength = max(minLength, 2*period)
+
+	start := 0
+	for i := period; i <= len(upper); i++ {
+		if i < len(upper) && upper[i] == upper[i-period] {
+			continue
+		}
+		// upper[start:i] is a maximal stretch with the given period
+		if i-start >= minLength && isRepeatUnit(upper[start:start+period]) {
+			spans = append(spans, repeatSpan{start: start, end: i, period: period})
+		}
+		start = i - period + 1
+	}
+	return spans
+}
+
+// isRepeatUnit reports whether a unit consists of nucleotides and has no shorter period
+func isRepeatUnit(unit string) bool {
+	for i := 0; i < len(unit); i++ {
+		if !strings.ContainsRune("ACGTU", rune(unit[i])) {
+			return false
+		}
+	}
+	for period := 1; period < len(unit); period++ {
+		if len(unit)%period == 0 && strings.Repeat(unit[:period], len(unit)/period) == unit {
+			return false
+		}
+	}
+	return true
+}
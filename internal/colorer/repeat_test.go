@@ -0,0 +1,36 @@
+package colorer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScanRepeats(t *testing.T) {
+	tests := []struct {
+		name      string
+		sequence  string
+		period    int
+		minLength int
+		want      []repeatSpan
+	}{
+		{"homopolymer", "GCAAAAAAATC", 1, 5, []repeatSpan{{2, 9, 1}}},
+		{"homopolymer too short", "GCAAAATC", 1, 5, nil},
+		{"homopolymer at both ends", "AAAAACGTTTTT", 1, 5, []repeatSpan{{0, 5, 1}, {7, 12, 1}}},
+		{"dinucleotide with partial copy", "TCACACACACG", 2, 8, []repeatSpan{{1, 10, 2}}},
+		{"pentanucleotide", "GGTTCAGTTCAGTTCAGN", 5, 10, []repeatSpan{{1, 17, 5}}},
+		{"single copy is not a repeat", "ACGTAC", 4, 1, nil},
+		{"homopolymer is not a dinucleotide repeat", "CAAAAAAAAC", 2, 6, nil},
+		{"ambiguous bases are skipped", "ANNNNNNNNA", 1, 5, nil},
+		{"gaps are skipped", "A--------A", 1, 5, nil},
+		{"minimum length of two copies", "ACGACG", 3, 1, []repeatSpan{{0, 6, 3}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scanRepeats(tt.sequence, tt.period, tt.minLength)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("scanRepeats(%q, %d, %d) = %v, want %v", tt.sequence, tt.period, tt.minLength, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,357 @@
+package colorer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/benekenobi/colordna/internal/parser"
+)
+
+// SAMFlagMode controls how SAM records are styled by their FLAG
+type SAMFlagMode int
+
+const (
+	SAMFlagsNone     SAMFlagMode = iota // FLAG is printed as is
+	SAMFlagsColor                       // Records are styled by FLAG
+	SAMFlagsAnnotate                    // Records are styled and FLAG is decoded into names
+)
+
+const (
+	forwardCode       = "\033[96m"        // Bright cyan QNAME for forward strand reads
+	reverseCode       = "\033[95m"        // Bright magenta QNAME for reverse strand reads
+	unmappedCode      = "\033[3m\033[90m" // Italic dark gray QNAME for unmapped reads
+	supplementaryCode = "\033[3m"         // Italic QNAME for supplementary alignments
+	properPairCode    = "\033[92m"        // Bright green FLAG for proper pairs
+	improperPairCode  = "\033[93m"        // Bright yellow FLAG for pairs not mapped properly
+)
+
+const (
+	tagNameCode      = "\033[36m"        // Cyan for tag names
+	wellKnownTagCode = "\033[1m\033[93m" // Bold bright yellow for well-known tag names
+	tagTypeCode      = "\033[90m"        // Dark gray for tag types and abbreviations
+)
+
+// wellKnownTags are highlighted optional field tags
+var wellKnownTags = map[string]bool{
+	"NM": true, "MD": true, "AS": true, "XS": true, "RG": true,
+	"MI": true, "CB": true, "UB": true,
+}
+
+// sequenceTags hold bases and are colored like the SEQ column
+var sequenceTags = map[string]bool{
+	"R2": true, "E2": true, "BC": true, "RX": true, "OX": true, "CR": true, "U2": true,
+}
+
+// qualityTags hold quality strings and are colored like the QUAL column
+var qualityTags = map[string]bool{
+	"Q2": true, "OQ": true, "QT": true, "QX": true, "CY": true, "BZ": true,
+}
+
+// cigarCodes maps CIGAR operations to their colors
+var cigarCodes = map[byte]string{
+	'M': "\033[92m", // Bright green - alignment match
+	'=': "\033[92m", // Bright green - sequence match
+	'X': "\033[91m", // Bright red - mismatch
+	'I': "\033[95m", // Bright magenta - insertion
+	'D': "\033[93m", // Bright yellow - deletion
+	'N': "\033[90m", // Dark gray - skipped region
+	'S': "\033[2m",  // Dim - soft clip
+	'H': "\033[2m",  // Dim - hard clip
+	'P': "\033[90m", // Dark gray - padding
+}
+
+// ParseSAMFlagMode parses a SAM flag mode name: none, color or annotate
+func ParseSAMFlagMode(name string) (SAMFlagMode, error) {
+	switch strings.ToLower(name) {
+	case "none", "":
+		return SAMFlagsNone, nil
+	case "color":
+		return SAMFlagsColor, nil
+	case "annotate":
+		return SAMFlagsAnnotate, nil
+	default:
+		return SAMFlagsNone, fmt.Errorf("unknown SAM flag mode '%s' (use none, color or annotate)", name)
+	}
+}
+
+// SetSAMFlagMode sets how ColorizeSAM styles records by their FLAG
+func (c *Colorer) SetSAMFlagMode(mode SAMFlagMode) {
+	c.samFlags = mode
+}
+
+// ColorizeSAM colorizes the sequence column in SAM format
+func (c *Colorer) ColorizeSAM(line string) string {
+	fields := strings.Split(line, "\t")
+	if len(fields) < 11 {
+		return line // Not enough fields for SAM format
+	}
+
+	// Field 1 (index 1) contains the FLAG
+	flag, flagErr := strconv.Atoi(fields[1])
+	if c.samFlags != SAMFlagsNone && flagErr == nil {
+		// Dim secondary, duplicate and QC-failed records as a whole
+		if flag&(parser.SAMFlagSecondary|parser.SAMFlagDuplicate|parser.SAMFlagQCFail) != 0 {
+			if c.samFlags == SAMFlagsAnnotate {
+				fields[1] = annotateSAMFlag(flag)
+			}
+			return dimCode + strings.Join(fields, "\t") + resetCode
+		}
+		c.colorizeSAMFlag(fields, flag)
+	}
+
+	// Field 4 (index 4) contains MAPQ, 255 means unavailable
+	if mapq, err := strconv.Atoi(fields[4]); err == nil && mapq != 255 {
+		if color := c.getQualityColor(mapq); color != "" {
+			fields[4] = color + fields[4] + resetCode
+		}
+	}
+
+	// Field 9 (index 9) contains the sequence, field 10 (index 10) the quality scores
+	sequence := fields[9]
+	quality := fields[10]
+	if sequence != "*" && parser.IsSequenceLine(sequence) {
+		if quality != "*" {
+			fields[9] = c.ColorizeMaskedSequence(sequence, quality)
+		} else {
+			fields[9] = c.ColorizeSequence(sequence)
+		}
+	}
+
+	if quality != "*" && parser.IsQualityLine(quality) {
+		fields[10] = c.ColorizeQuality(quality)
+	}
+
+	// Fields 11 and beyond contain optional TAG:TYPE:VALUE fields
+	for i := 11; i < len(fields); i++ {
+		fields[i] = c.colorizeSAMTag(fields[i])
+	}
+
+	return strings.Join(fields, "\t")
+}
+
+// SetMaxArrayValues sets how many values of a SAM B array tag are shown before it is
+// abbreviated, 0 shows all values
+func (c *Colorer) SetMaxArrayValues(n int) {
+	c.maxArrayValues = n
+}
+
+// colorizeSAMTag colors the name and type of an optional field, highlighting well-known
+// tags and coloring sequence, quality and CIGAR values like the main columns
+func (c *Colorer) colorizeSAMTag(field string) string {
+	parts := strings.SplitN(field, ":", 3)
+	if len(parts) != 3 || len(parts[0]) != 2 || len(parts[1]) != 1 {
+		return field // Not a TAG:TYPE:VALUE field
+	}
+	tag, typ, value := parts[0], parts[1], parts[2]
+
+	nameCode := tagNameCode
+	if wellKnownTags[tag] {
+		nameCode = wellKnownTagCode
+	}
+
+	switch {
+	case typ == "B":
+		value = c.abbreviateArray(value)
+	case sequenceTags[tag] && parser.IsSequenceLine(value):
+		value = c.ColorizeSequence(value)
+	case qualityTags[tag] && parser.IsQualityLine(value):
+		value = c.ColorizeQuality(value)
+	case tag == "MC":
+		value = colorizeCIGAR(value)
+	case tag == "SA":
+		value = colorizeSupplementary(value)
+	}
+
+	return nameCode + tag + resetCode + ":" + tagTypeCode + typ + resetCode + ":" + value
+}
+
+// abbreviateArray shortens a B array value ("c,1,2,...") to its subtype, the first
+// values and the number of values left out
+func (c *Colorer) abbreviateArray(value string) string {
+	values := strings.Split(value, ",")
+	count := len(values) - 1 // The first entry is the subtype
+	if c.maxArrayValues <= 0 || count <= c.maxArrayValues {
+		return value
+	}
+	shown := strings.Join(values[:c.maxArrayValues+1], ",")
+	return fmt.Sprintf("%s,%s…(+%d)%s", shown, tagTypeCode, count-c.maxArrayValues, resetCode)
+}
+
+// colorizeSupplementary colors the CIGARs in an SA tag ("rname,pos,strand,CIGAR,mapQ,NM;...")
+func colorizeSupplementary(value string) string {
+	alignments := strings.Split(value, ";")
+	for i, alignment := range alignments {
+		parts := strings.Split(alignment, ",")
+		if len(parts) == 6 {
+			parts[3] = colorizeCIGAR(parts[3])
+			alignments[i] = strings.Join(parts, ",")
+		}
+	}
+	return strings.Join(alignments, ";")
+}
+
+// colorizeCIGAR colors the operations of a CIGAR string
+func colorizeCIGAR(cigar string) string {
+	if cigar == "*" {
+		return cigar
+	}
+
+	var result strings.Builder
+	start := 0
+	for i := 0; i < len(cigar); i++ {
+		if cigar[i] >= '0' && cigar[i] <= '9' {
+			continue
+		}
+		code, ok := cigarCodes[cigar[i]]
+		if !ok {
+			return cigar // Not a valid CIGAR
+		}
+		result.WriteString(code)
+		result.WriteString(cigar[start : i+1])
+		result.WriteString(resetCode)
+		start = i + 1
+	}
+	if start < len(cigar) {
+		return cigar // Trailing length without an operation
+	}
+	return result.String()
+}
+
+// colorizeSAMFlag styles QNAME by strand and mapping state, and FLAG by pairing
+func (c *Colorer) colorizeSAMFlag(fields []string, flag int) {
+	var nameCode string
+	switch {
+	case flag&parser.SAMFlagUnmapped != 0:
+		nameCode = unmappedCode
+	case flag&parser.SAMFlagReverse != 0:
+		nameCode = reverseCode
+	default:
+		nameCode = forwardCode
+	}
+	if flag&parser.SAMFlagSupplementary != 0 {
+		nameCode += supplementaryCode
+	}
+	fields[0] = nameCode + fields[0] + resetCode
+
+	if c.samFlags == SAMFlagsAnnotate {
+		fields[1] = annotateSAMFlag(flag)
+	}
+	if flag&parser.SAMFlagPaired != 0 {
+		if flag&parser.SAMFlagProperPair != 0 {
+			fields[1] = properPairCode + fields[1] + resetCode
+		} else {
+			fields[1] = improperPairCode + fields[1] + resetCode
+		}
+	}
+}
+
+// annotateSAMFlag appends the decoded flag names to a FLAG value, e.g. "16(reverse)"
+func annotateSAMFlag(flag int) string {
+	names := parser.DecodeSAMFlag(flag)
+	if len(names) == 0 {
+		return strconv.Itoa(flag)
+	}
+	return strconv.Itoa(flag) + "(" + strings.Join(names, ",") + ")"
+}
+
+// samHeaderCodes maps SAM header record types to their colors
+var samHeaderCodes = map[string]string{
+	"@HD": "\033[1m\033[97m", // Bold bright white
+	"@SQ": "\033[1m\033[94m", // Bold bright blue
+	"@RG": "\033[1m\033[92m", // Bold bright green
+	"@PG": "\033[1m\033[95m", // Bold bright magenta
+}
+
+// summaryTopReferences is the number of longest references listed in a header summary
+const summaryTopReferences = 5
+
+// ColorizeSAMHeader colors a SAM header line by record type and highlights its tag keys
+func (c *Colorer) ColorizeSAMHeader(line string) string {
+	fields := strings.Split(line, "\t")
+	if fields[0] == "@CO" {
+		return tagTypeCode + line + resetCode
+	}
+
+	code, ok := samHeaderCodes[fields[0]]
+	if !ok {
+		return line
+	}
+	fields[0] = code + fields[0] + resetCode
+	for i := 1; i < len(fields); i++ {
+		if key, value, found := strings.Cut(fields[i], ":"); found {
+			fields[i] = tagNameCode + key + resetCode + ":" + value
+		}
+	}
+	return strings.Join(fields, "\t")
+}
+
+// SAMHeaderSummary renders the @SQ records of a header as a compact table and the
+// @PG records as a numbered program history
+func (c *Colorer) SAMHeaderSummary(header *parser.SAMHeader) []string {
+	var lines []string
+
+	if len(header.References) > 0 {
+		total := 0
+		for _, ref := range header.References {
+			total += ref.Length
+		}
+		lines = append(lines, fmt.Sprintf("%s\t%d reference sequences, %s bp total",
+			c.ColorizeSAMHeader("@SQ"), len(header.References), formatThousands(total)))
+
+		refs := make([]parser.SAMReference, len(header.References))
+		copy(refs, header.References)
+		sort.SliceStable(refs, func(i, j int) bool { return refs[i].Length > refs[j].Length })
+		if len(refs) > summaryTopReferences {
+			refs = refs[:summaryTopReferences]
+		}
+		width := 0
+		for _, ref := range refs {
+			if len(ref.Name) > width {
+				width = len(ref.Name)
+			}
+		}
+		for _, ref := range refs {
+			lines = append(lines, fmt.Sprintf("\t%s%-*s%s  %15s bp",
+				tagNameCode, width, ref.Name, resetCode, formatThousands(ref.Length)))
+		}
+		if len(header.References) > len(refs) {
+			lines = append(lines, fmt.Sprintf("\t%s... %d more%s",
+				tagTypeCode, len(header.References)-len(refs), resetCode))
+		}
+	}
+
+	if len(header.Programs) > 0 {
+		lines = append(lines, fmt.Sprintf("%s\t%d program(s)", c.ColorizeSAMHeader("@PG"), len(header.Programs)))
+		for i, pg := range header.ProgramChain() {
+			name := pg.Name
+			if name == "" {
+				name = pg.ID
+			}
+			if pg.Version != "" {
+				name += " " + pg.Version
+			}
+			line := fmt.Sprintf("\t%d. %s%s%s", i+1, samHeaderCodes["@PG"], name, resetCode)
+			if pg.CommandLine != "" {
+				line += fmt.Sprintf(": %s", pg.CommandLine)
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+// formatThousands formats a number with comma thousands separators
+func formatThousands(n int) string {
+	digits := strconv.Itoa(n)
+	var result strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			result.WriteByte(',')
+		}
+		result.WriteRune(digit)
+	}
+	return result.String()
+}
@@ -0,0 +1,30 @@
+package colorer
+
+import "testing"
+
+func TestColorizeCIGAR(t *testing.T) {
+	tests := []struct {
+		name  string
+		cigar string
+		want  string
+	}{
+		{"missing", "*", "*"},
+		{"match", "10M", cigarCodes['M'] + "10M" + resetCode},
+		{
+			"clip and match",
+			"5S10M",
+			cigarCodes['S'] + "5S" + resetCode + cigarCodes['M'] + "10M" + resetCode,
+		},
+		{"trailing length", "10M5", "10M5"},
+		{"length only", "5", "5"},
+		{"unknown operation", "10Q", "10Q"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := colorizeCIGAR(tt.cigar); got != tt.want {
+				t.Errorf("colorizeCIGAR(%q) = %q, want %q", tt.cigar, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package colorer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/benekenobi/colordna/internal/parser"
+)
+
+// Transcription converts sequences between DNA and RNA before coloring
+type Transcription int
+
+const (
+	TranscriptionNone Transcription = iota // Sequences are shown as read
+	TranscriptionRNA                       // DNA is transcribed to RNA (T to U)
+	TranscriptionDNA                       // RNA is back-transcribed to DNA (U to T)
+)
+
+// SetTransform sets the strand and transcription view applied by TransformSequence,
+// TransformQuality and AnnotateHeader
+func (c *Colorer) SetTransform(reverseComplement bool, transcription Transcription) {
+	c.reverseComplement = reverseComplement
+	c.transcription = transcription
+}
+
+// TransformSequence returns a whole sequence in the configured view: reverse
+// complemented, transcribed or back-transcribed. RNA stays RNA when reverse complemented.
+func (c *Colorer) TransformSequence(sequence string) string {
+	if c.reverseComplement {
+		rna := strings.ContainsAny(sequence, "Uu")
+		sequence = parser.ReverseComplement(sequence)
+		if rna {
+			sequence = parser.Transcribe(sequence)
+		}
+	}
+	switch c.transcription {
+	case TranscriptionRNA:
+		sequence = parser.Transcribe(sequence)
+	case TranscriptionDNA:
+		sequence = parser.BackTranscribe(sequence)
+	}
+	return sequence
+}
+
+// TransformQuality reverses a quality string to match a reverse-complemented sequence
+func (c *Colorer) TransformQuality(quality string) string {
+	if !c.reverseComplement {
+		return quality
+	}
+	reversed := []byte(quality)
+	for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+		reversed[i], reversed[j] = reversed[j], reversed[i]
+	}
+	return string(reversed)
+}
+
+// AnnotateHeader marks a FASTA/FASTQ header of a reverse-complemented record by
+// appending "/rc" to the sequence name, as samtools faidx -i does
+func (c *Colorer) AnnotateHeader(header string) string {
+	if !c.reverseComplement || len(header) < 2 {
+		return header
+	}
+	name, description, found := strings.Cut(header, " ")
+	if found {
+		return fmt.Sprintf("%s/rc %s", name, description)
+	}
+	return name + "/rc"
+}
@@ -0,0 +1,332 @@
+package colorer
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/benekenobi/colordna/internal/parser"
+)
+
+const (
+	homRefCode   = "\033[2m"         // Dim for homozygous reference
+	hetCode      = "\033[93m"        // Bright yellow for heterozygous
+	homAltCode   = "\033[1m\033[95m" // Bold bright magenta for homozygous alternative
+	missingCode  = "\033[90m"        // Dark gray for missing genotypes
+	phasedCode   = "\033[1m\033[96m" // Bold bright cyan for the phased separator '|'
+	unphasedCode = "\033[90m"        // Dark gray for the unphased separator '/'
+	passCode     = "\033[92m"        // Bright green for values meeting a threshold
+	failCode     = "\033[91m"        // Bright red for values below a threshold
+
+	filterPassCode = "\033[1m\033[92m" // Bold bright green for PASS
+	filterFailCode = "\033[1m\033[91m" // Bold bright red for failing filters
+)
+
+// variantTypeCodes maps variant classes to the style of their ALT allele
+var variantTypeCodes = map[parser.VariantType]string{
+	parser.VariantTransition:   "\033[92m",        // Bright green
+	parser.VariantTransversion: "\033[96m",        // Bright cyan
+	parser.VariantMNV:          "\033[94m",        // Bright blue
+	parser.VariantInsertion:    "\033[95m",        // Bright magenta
+	parser.VariantDeletion:     "\033[91m",        // Bright red
+	parser.VariantSymbolic:     "\033[3m\033[93m", // Italic bright yellow
+}
+
+const (
+	infoKeyCode        = "\033[36m"        // Cyan for declared INFO keys
+	infoFlagCode       = "\033[1m\033[35m" // Bold magenta for Flag INFO keys
+	infoUndeclaredCode = "\033[3m\033[90m" // Italic dark gray for keys missing from the header
+	invalidValueCode   = "\033[4m\033[91m" // Underlined bright red for values not matching the header
+	infoBelowCode      = "\033[2m"         // Dim marker for INFO listed beneath the record
+)
+
+// SetVCFHeader sets the header definitions used to render and validate INFO fields.
+// The header may be filled in after it is set, as header lines are read.
+func (c *Colorer) SetVCFHeader(header *parser.VCFHeader) {
+	c.vcfHeader = header
+}
+
+// SetInfoKeys restricts the rendered INFO field to the given keys, all keys when empty
+func (c *Colorer) SetInfoKeys(keys []string) {
+	c.infoKeys = keys
+}
+
+// SetInfoAligned lists INFO pairs one per line beneath each record, with aligned values,
+// instead of inline
+func (c *Colorer) SetInfoAligned(enabled bool) {
+	c.infoAligned = enabled
+}
+
+// SetVariantTypes enables coloring VCF ALT alleles by variant class instead of by nucleotide
+func (c *Colorer) SetVariantTypes(enabled bool) {
+	c.variantTypes = enabled
+}
+
+// SetGenotypeThresholds sets the DP and GQ thresholds used to color VCF sample fields.
+// A threshold of 0 disables coloring of that field.
+func (c *Colorer) SetGenotypeThresholds(minDepth, minGQ int) {
+	c.minDepth = minDepth
+	c.minGQ = minGQ
+}
+
+// ColorizeVCF colorizes relevant fields in VCF format
+func (c *Colorer) ColorizeVCF(line string) string {
+	fields := strings.Split(line, "\t")
+	if len(fields) < 5 {
+		return line // Not enough fields for VCF format
+	}
+
+	// Field 3 (index 3) contains the reference allele
+	ref := fields[3]
+	if ref != "." && parser.IsSequenceLine(ref) {
+		fields[3] = c.ColorizeSequence(ref)
+	}
+
+	// Field 4 (index 4) contains the alternative allele(s)
+	if alt := fields[4]; alt != "." {
+		alternatives := strings.Split(alt, ",")
+		for i, allele := range alternatives {
+			if c.variantTypes {
+				if code, ok := variantTypeCodes[parser.ClassifyVariant(ref, allele)]; ok {
+					alternatives[i] = code + allele + resetCode
+				}
+			} else if parser.IsSequenceLine(allele) {
+				alternatives[i] = c.ColorizeSequence(allele)
+			}
+		}
+		fields[4] = strings.Join(alternatives, ",")
+	}
+
+	// Field 5 (index 5) contains the Phred-scaled QUAL
+	if len(fields) > 5 {
+		if qual, err := strconv.ParseFloat(fields[5], 64); err == nil {
+			if color := c.getQualityColor(int(qual)); color != "" {
+				fields[5] = color + fields[5] + resetCode
+			}
+		}
+	}
+
+	// Field 6 (index 6) contains FILTER
+	if len(fields) > 6 {
+		switch filter := fields[6]; filter {
+		case ".":
+		case "PASS":
+			fields[6] = filterPassCode + filter + resetCode
+		default:
+			fields[6] = filterFailCode + filter + resetCode
+		}
+	}
+
+	// Field 7 (index 7) contains INFO, listed beneath the record when aligned
+	var info []infoPair
+	if len(fields) > 7 {
+		altCount := len(strings.Split(fields[4], ","))
+		if c.infoAligned {
+			if info = c.infoPairs(fields[7], altCount); len(info) > 0 {
+				fields[7] = infoBelowCode + "↓" + resetCode
+			}
+		} else {
+			fields[7] = c.colorizeInfo(fields[7], altCount)
+		}
+	}
+
+	// Field 8 (index 8) contains FORMAT, followed by one column per sample
+	if len(fields) > 9 {
+		keys := strings.Split(fields[8], ":")
+		for i := 9; i < len(fields); i++ {
+			fields[i] = c.colorizeSample(keys, fields[i])
+		}
+	}
+
+	if len(info) > 0 {
+		return strings.Join(fields, "\t") + "\n" + alignedInfo(info)
+	}
+	return strings.Join(fields, "\t")
+}
+
+// infoPair is a colored INFO key and its value, empty for flags
+type infoPair struct {
+	key, value string
+	keyLength  int // Length of the key without color codes
+	hasValue   bool
+}
+
+// infoPairs colors INFO keys, distinguishes flags and marks values that do not match
+// the header definition, keeping only the selected keys if any were set
+func (c *Colorer) infoPairs(info string, altCount int) []infoPair {
+	if info == "." {
+		return nil
+	}
+
+	var pairs []infoPair
+	for _, pair := range strings.Split(info, ";") {
+		key, value, hasValue := strings.Cut(pair, "=")
+		if len(c.infoKeys) > 0 && !containsKey(c.infoKeys, key) {
+			continue
+		}
+
+		var field parser.VCFField
+		declared := false
+		if c.vcfHeader != nil {
+			field, declared = c.vcfHeader.Info[key]
+		}
+
+		keyCode := infoKeyCode
+		switch {
+		case !declared:
+			keyCode = infoUndeclaredCode
+		case field.Type == "Flag":
+			keyCode = infoFlagCode
+		}
+		if !hasValue && declared && field.Type != "Flag" {
+			keyCode = invalidValueCode
+		}
+		if hasValue && declared && !field.ValidValue(value, altCount) {
+			value = invalidValueCode + value + resetCode
+		}
+		pairs = append(pairs, infoPair{
+			key:       keyCode + key + resetCode,
+			value:     value,
+			keyLength: len(key),
+			hasValue:  hasValue,
+		})
+	}
+	return pairs
+}
+
+// colorizeInfo renders INFO pairs inline, as in the input
+func (c *Colorer) colorizeInfo(info string, altCount int) string {
+	pairs := c.infoPairs(info, altCount)
+	if len(pairs) == 0 {
+		return "."
+	}
+
+	rendered := make([]string, len(pairs))
+	for i, pair := range pairs {
+		rendered[i] = pair.key
+		if pair.hasValue {
+			rendered[i] += "=" + pair.value
+		}
+	}
+	return strings.Join(rendered, ";")
+}
+
+// alignedInfo renders INFO pairs one per line beneath the record, with the values
+// aligned after the longest key
+func alignedInfo(pairs []infoPair) string {
+	width := 0
+	for _, pair := range pairs {
+		width = max(width, pair.keyLength)
+	}
+
+	lines := make([]string, len(pairs))
+	for i, pair := range pairs {
+		lines[i] = "  " + pair.key
+		if pair.hasValue {
+			lines[i] += strings.Repeat(" ", width-pair.keyLength) + " = " + pair.value
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// containsKey reports whether keys contains key
+func containsKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// colorizeSample colorizes the GT, DP and GQ values of a VCF sample column
+func (c *Colorer) colorizeSample(keys []string, sample string) string {
+	values := strings.Split(sample, ":")
+	for i, value := range values {
+		if i >= len(keys) {
+			break
+		}
+		switch keys[i] {
+		case "GT":
+			values[i] = colorizeGenotype(value)
+		case "DP":
+			values[i] = colorizeThreshold(value, c.minDepth)
+		case "GQ":
+			values[i] = colorizeThreshold(value, c.minGQ)
+		}
+	}
+	return strings.Join(values, ":")
+}
+
+// colorizeGenotype styles a GT value by zygosity, and its separators by phasing
+func colorizeGenotype(gt string) string {
+	var alleles []string
+	var separators []byte
+	start := 0
+	for i := 0; i < len(gt); i++ {
+		if gt[i] == '/' || gt[i] == '|' {
+			alleles = append(alleles, gt[start:i])
+			separators = append(separators, gt[i])
+			start = i + 1
+		}
+	}
+	alleles = append(alleles, gt[start:])
+
+	code := genotypeCode(alleles)
+
+	var result strings.Builder
+	for i, allele := range alleles {
+		if i > 0 {
+			if separators[i-1] == '|' {
+				result.WriteString(phasedCode)
+			} else {
+				result.WriteString(unphasedCode)
+			}
+			result.WriteByte(separators[i-1])
+			result.WriteString(resetCode)
+		}
+		result.WriteString(code)
+		result.WriteString(allele)
+		result.WriteString(resetCode)
+	}
+	return result.String()
+}
+
+// genotypeCode returns the style for a genotype's zygosity
+func genotypeCode(alleles []string) string {
+	homRef, sameAllele := true, true
+	for _, allele := range alleles {
+		if allele == "." || allele == "" {
+			return missingCode
+		}
+		if allele != "0" {
+			homRef = false
+		}
+		if allele != alleles[0] {
+			sameAllele = false
+		}
+	}
+
+	switch {
+	case homRef:
+		return homRefCode
+	case sameAllele:
+		return homAltCode
+	default:
+		return hetCode
+	}
+}
+
+// colorizeThreshold colors a numeric value by whether it reaches the threshold
+func colorizeThreshold(value string, threshold int) string {
+	if threshold <= 0 {
+		return value
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return value // Missing or non-numeric value
+	}
+	if number >= float64(threshold) {
+		return passCode + value + resetCode
+	}
+	return failCode + value + resetCode
+}
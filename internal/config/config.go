@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -18,11 +19,19 @@ type ColorScheme struct {
 	N          string `yaml:"n"`          // Unknown/ambiguous nucleotide
 	Quality    string `yaml:"quality"`    // Quality score color scheme
 	Background bool   `yaml:"background"` // Whether to use background colors
+
+	QualityBins     []QualityBin     `yaml:"quality_bins,omitempty"`     // Custom quality bins
+	QualityGradient *QualityGradient `yaml:"quality_gradient,omitempty"` // Truecolor quality gradient
+	QualityPreset   string           `yaml:"quality_preset,omitempty"`   // Named quality bins or gradient
+	GroupSeparator  string           `yaml:"group_separator,omitempty"`  // Text between groups of bases
+	GroupGap        string           `yaml:"group_gap,omitempty"`        // Text between larger blocks of groups
 }
 
 // Config represents the application configuration
 type Config struct {
 	ColorSchemes map[string]ColorScheme `yaml:"color_schemes"`
+	Adapters     map[string][]string    `yaml:"adapters"` // Named adapter sets
+	Enzymes      map[string]string      `yaml:"enzymes"`  // Recognition sites, '^' marks the cut position
 }
 
 // Default color schemes
@@ -69,6 +78,47 @@ var defaultConfig = Config{
 			Background: false,
 		},
 	},
+	Adapters: map[string][]string{
+		"illumina": {
+			"AGATCGGAAGAGCACACGTCTGAACTCCAGTCA", // TruSeq read 1
+			"AGATCGGAAGAGCGTCGTGTAGGGAAAGAGTGT", // TruSeq read 2
+		},
+		"nextera": {
+			"CTGTCTCTTATACACATCT", // Nextera transposase
+		},
+		"smallrna": {
+			"TGGAATTCTCGGGTGCCAAGG", // Illumina small RNA 3' adapter
+		},
+		"polya": {
+			"AAAAAAAAAAAAAAAAAAAA", // Poly-A tail
+		},
+	},
+	Enzymes: map[string]string{
+		"AluI":    "AG^CT",
+		"BamHI":   "G^GATCC",
+		"BglII":   "A^GATCT",
+		"BsaI":    "GGTCTC^N",
+		"ClaI":    "AT^CGAT",
+		"DpnII":   "^GATC",
+		"EcoRI":   "G^AATTC",
+		"EcoRV":   "GAT^ATC",
+		"HaeIII":  "GG^CC",
+		"HindIII": "A^AGCTT",
+		"HinfI":   "G^ANTC",
+		"KpnI":    "GGTAC^C",
+		"MspI":    "C^CGG",
+		"NcoI":    "C^CATGG",
+		"NdeI":    "CA^TATG",
+		"NheI":    "G^CTAGC",
+		"NotI":    "GC^GGCCGC",
+		"PstI":    "CTGCA^G",
+		"SacI":    "GAGCT^C",
+		"SalI":    "G^TCGAC",
+		"SmaI":    "CCC^GGG",
+		"SpeI":    "A^CTAGT",
+		"XbaI":    "T^CTAGA",
+		"XhoI":    "C^TCGAG",
+	},
 }
 
 // Load loads the configuration from the specified file, or returns default config if file doesn't exist
@@ -109,6 +159,12 @@ func LoadWithVerbose(configPath string, verbose bool) (*Config, error) {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
+	for name, scheme := range config.ColorSchemes {
+		if err := scheme.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid color scheme '%s': %w", name, err)
+		}
+	}
+
 	if verbose {
 		fmt.Fprintf(os.Stderr, "Config file parsed successfully\n")
 		fmt.Fprintf(os.Stderr, "Found %d color scheme(s) in config file\n", len(config.ColorSchemes))
@@ -130,9 +186,48 @@ func LoadWithVerbose(configPath string, verbose bool) (*Config, error) {
 		fmt.Fprintf(os.Stderr, "Merged %d default color scheme(s)\n", mergedSchemes)
 	}
 
+	// Merge built-in adapter and enzyme libraries, config entries take precedence. Names
+	// are compared ignoring case, as they are looked up, so "ecori" overrides "EcoRI".
+	for name, sequences := range defaultConfig.Adapters {
+		if _, exists := config.Adapter(name); !exists {
+			if config.Adapters == nil {
+				config.Adapters = make(map[string][]string)
+			}
+			config.Adapters[name] = sequences
+		}
+	}
+	for name, site := range defaultConfig.Enzymes {
+		if _, exists := config.Enzyme(name); !exists {
+			if config.Enzymes == nil {
+				config.Enzymes = make(map[string]string)
+			}
+			config.Enzymes[name] = site
+		}
+	}
+
 	return &config, nil
 }
 
+// Adapter looks up a named adapter set, ignoring case
+func (c *Config) Adapter(name string) ([]string, bool) {
+	for key, sequences := range c.Adapters {
+		if strings.EqualFold(key, name) {
+			return sequences, true
+		}
+	}
+	return nil, false
+}
+
+// Enzyme looks up a restriction enzyme recognition site, ignoring case
+func (c *Config) Enzyme(name string) (string, bool) {
+	for key, site := range c.Enzymes {
+		if strings.EqualFold(key, name) {
+			return site, true
+		}
+	}
+	return "", false
+}
+
 // createDefaultConfig creates a default configuration file
 func createDefaultConfig(configPath string) error {
 	// Create directory if it doesn't exist
@@ -158,6 +253,13 @@ func createDefaultConfig(configPath string) error {
 #
 # You can create custom color schemes by adding new entries under color_schemes.
 # The 'bright' scheme is the default and uses only font colors (no backgrounds).
+#
+# Quality colors can be customized per scheme with quality_bins (min + color),
+# quality_gradient (min, max and hex stops for truecolor terminals) or
+# quality_preset (default, mono, illumina-binned, long-read, viridis, magma).
+#
+# Adapter sets (--adapters) and restriction enzymes (--enzymes) can be extended
+# under adapters and enzymes. Enzyme sites use IUPAC codes with '^' marking the cut.
 
 `
 
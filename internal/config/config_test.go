@@ -0,0 +1,33 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadMergesLibrariesIgnoringCase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "colordna.yaml")
+	data := "enzymes:\n  ecori: \"GAA^TTC\"\nadapters:\n  NEXTERA: [\"ACGT\"]\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if len(cfg.Enzymes) != len(defaultConfig.Enzymes) {
+		t.Errorf("got %d enzymes, want %d", len(cfg.Enzymes), len(defaultConfig.Enzymes))
+	}
+	if site, _ := cfg.Enzyme("EcoRI"); site != "GAA^TTC" {
+		t.Errorf("Enzyme(EcoRI) = %q, want the config entry GAA^TTC", site)
+	}
+	if sequences, _ := cfg.Adapter("nextera"); len(sequences) != 1 || sequences[0] != "ACGT" {
+		t.Errorf("Adapter(nextera) = %v, want the config entry [ACGT]", sequences)
+	}
+	if _, exists := cfg.Enzyme("BamHI"); !exists {
+		t.Error("built-in enzyme BamHI was not merged")
+	}
+}
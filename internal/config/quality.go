@@ -0,0 +1,168 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// QualityBin colors quality scores at or above Min
+type QualityBin struct {
+	Min   int    `yaml:"min"`   // Lowest Phred score in the bin
+	Color string `yaml:"color"` // ANSI escape sequence for the bin
+}
+
+// QualityGradient maps a Phred range onto a continuous truecolor gradient
+type QualityGradient struct {
+	Min   int      `yaml:"min"`   // Phred score mapped to the first stop
+	Max   int      `yaml:"max"`   // Phred score mapped to the last stop
+	Stops []string `yaml:"stops"` // Hex colors such as "#440154"
+}
+
+// QualityPreset is a named set of quality bins or a quality gradient
+type QualityPreset struct {
+	Bins     []QualityBin
+	Gradient *QualityGradient
+}
+
+// Built-in quality presets
+var qualityPresets = map[string]QualityPreset{
+	"default": {
+		Bins: []QualityBin{
+			{Min: 40, Color: "\033[92m"}, // Bright green - excellent quality
+			{Min: 30, Color: "\033[32m"}, // Green - good quality
+			{Min: 20, Color: "\033[93m"}, // Yellow - acceptable quality
+			{Min: 10, Color: "\033[91m"}, // Red - poor quality
+			{Min: 0, Color: "\033[31m"},  // Dark red - very poor quality
+		},
+	},
+	"mono": {
+		Bins: []QualityBin{
+			{Min: 30, Color: "\033[1m"}, // Bold for high quality
+			{Min: 20, Color: "\033[0m"}, // Normal for medium quality
+			{Min: 0, Color: "\033[2m"},  // Dim for low quality
+		},
+	},
+	"illumina-binned": {
+		// NovaSeq/HiSeq X style binning (Q2, Q12, Q23, Q37)
+		Bins: []QualityBin{
+			{Min: 37, Color: "\033[92m"}, // Bright green
+			{Min: 23, Color: "\033[93m"}, // Yellow
+			{Min: 12, Color: "\033[91m"}, // Red
+			{Min: 0, Color: "\033[31m"},  // Dark red
+		},
+	},
+	"long-read": {
+		// ONT and PacBio CLR reads rarely exceed Q20
+		Bins: []QualityBin{
+			{Min: 20, Color: "\033[92m"}, // Bright green
+			{Min: 15, Color: "\033[32m"}, // Green
+			{Min: 10, Color: "\033[93m"}, // Yellow
+			{Min: 7, Color: "\033[91m"},  // Red
+			{Min: 0, Color: "\033[31m"},  // Dark red
+		},
+	},
+	"viridis": {
+		Gradient: &QualityGradient{
+			Min:   0,
+			Max:   40,
+			Stops: []string{"#440154", "#3b528b", "#21918c", "#5ec962", "#fde725"},
+		},
+	},
+	"magma": {
+		Gradient: &QualityGradient{
+			Min:   0,
+			Max:   40,
+			Stops: []string{"#000004", "#51127c", "#b73779", "#fc8961", "#fcfdbf"},
+		},
+	},
+}
+
+// GetQualityPreset looks up a built-in quality preset by name
+func GetQualityPreset(name string) (QualityPreset, bool) {
+	preset, exists := qualityPresets[strings.ToLower(name)]
+	return preset, exists
+}
+
+// QualityPresetNames returns the names of the built-in quality presets in sorted order
+func QualityPresetNames() []string {
+	names := make([]string, 0, len(qualityPresets))
+	for name := range qualityPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveQuality returns the quality bins or gradient a scheme uses, in order of
+// precedence: explicit gradient, explicit bins, named preset, then the built-in
+// bins for the scheme's quality mode. Returned bins are sorted by descending Min.
+func (s ColorScheme) ResolveQuality() ([]QualityBin, *QualityGradient) {
+	if s.QualityGradient != nil {
+		return nil, s.QualityGradient
+	}
+
+	bins := s.QualityBins
+	if len(bins) == 0 {
+		name := s.QualityPreset
+		if name == "" {
+			name = "default"
+			if s.Quality == "mono" {
+				name = "mono"
+			}
+		}
+		preset, exists := GetQualityPreset(name)
+		if !exists {
+			preset = qualityPresets["default"]
+		}
+		if preset.Gradient != nil {
+			return nil, preset.Gradient
+		}
+		bins = preset.Bins
+	}
+
+	sorted := make([]QualityBin, len(bins))
+	copy(sorted, bins)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Min > sorted[j].Min })
+	return sorted, nil
+}
+
+// Validate checks the quality settings of a color scheme
+func (s ColorScheme) Validate() error {
+	if s.QualityPreset != "" {
+		if _, exists := GetQualityPreset(s.QualityPreset); !exists {
+			return fmt.Errorf("unknown quality preset '%s' (available: %s)",
+				s.QualityPreset, strings.Join(QualityPresetNames(), ", "))
+		}
+	}
+
+	if g := s.QualityGradient; g != nil {
+		if len(g.Stops) < 2 {
+			return fmt.Errorf("quality gradient needs at least two stops")
+		}
+		if g.Max <= g.Min {
+			return fmt.Errorf("quality gradient max (%d) must be greater than min (%d)", g.Max, g.Min)
+		}
+		for _, stop := range g.Stops {
+			if _, _, _, err := ParseHexColor(stop); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ParseHexColor parses a "#rrggbb" color into its components
+func ParseHexColor(hex string) (r, g, b uint8, err error) {
+	value := strings.TrimPrefix(hex, "#")
+	if len(value) != 6 {
+		return 0, 0, 0, fmt.Errorf("invalid hex color '%s'", hex)
+	}
+	rgb, err := strconv.ParseUint(value, 16, 32)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid hex color '%s'", hex)
+	}
+	return uint8(rgb >> 16), uint8(rgb >> 8), uint8(rgb), nil
+}
@@ -0,0 +1,179 @@
+package parser
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// FAIEntry is one record of a samtools-compatible FASTA index (.fai)
+type FAIEntry struct {
+	Name      string // Sequence name, the first word of the header
+	Length    int    // Number of bases in the sequence
+	Offset    int64  // Byte offset of the first base
+	LineBases int    // Bases per sequence line
+	LineWidth int    // Bytes per sequence line, including the line terminator
+}
+
+// BuildFAI scans a FASTA file and returns its index entries. Like samtools faidx, every
+// sequence line except the last of a record must have the same length.
+func BuildFAI(reader io.Reader) ([]FAIEntry, error) {
+	buffered := bufio.NewReader(reader)
+	var entries []FAIEntry
+	var entry *FAIEntry
+	var offset int64
+	lineNumber := 0
+	shortLine := false
+
+	for {
+		line, err := buffered.ReadString('\n')
+		if len(line) == 0 && err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		lineNumber++
+		width := len(line)
+		text := strings.TrimRight(line, "\r\n")
+		offset += int64(width)
+
+		if strings.HasPrefix(text, ">") {
+			name := strings.Fields(text[1:])
+			if len(name) == 0 {
+				return nil, fmt.Errorf("line %d: FASTA header without a name", lineNumber)
+			}
+			entries = append(entries, FAIEntry{Name: name[0], Offset: offset})
+			entry = &entries[len(entries)-1]
+			shortLine = false
+			continue
+		}
+		if entry == nil {
+			if text == "" {
+				continue
+			}
+			return nil, fmt.Errorf("line %d: sequence before the first FASTA header", lineNumber)
+		}
+		if text == "" {
+			shortLine = true
+			continue
+		}
+
+		if entry.LineBases == 0 {
+			entry.LineBases = len(text)
+			entry.LineWidth = width
+		} else if shortLine || len(text) > entry.LineBases ||
+			(len(text) == entry.LineBases && width != entry.LineWidth) {
+			return nil, fmt.Errorf("line %d: different line length in sequence '%s'", lineNumber, entry.Name)
+		}
+		if len(text) < entry.LineBases {
+			shortLine = true
+		}
+		entry.Length += len(text)
+	}
+
+	return entries, nil
+}
+
+// WriteFAI writes index entries in the five-column .fai format
+func WriteFAI(writer io.Writer, entries []FAIEntry) error {
+	for _, entry := range entries {
+		_, err := fmt.Fprintf(writer, "%s\t%d\t%d\t%d\t%d\n",
+			entry.Name, entry.Length, entry.Offset, entry.LineBases, entry.LineWidth)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadFAI parses a .fai index
+func ReadFAI(reader io.Reader) ([]FAIEntry, error) {
+	scanner := bufio.NewScanner(reader)
+	var entries []FAIEntry
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) < 5 {
+			return nil, fmt.Errorf("line %d: expected 5 index columns, found %d", lineNumber, len(fields))
+		}
+		entry := FAIEntry{Name: fields[0]}
+		var errs [4]error
+		entry.Length, errs[0] = strconv.Atoi(fields[1])
+		entry.Offset, errs[1] = strconv.ParseInt(fields[2], 10, 64)
+		entry.LineBases, errs[2] = strconv.Atoi(fields[3])
+		entry.LineWidth, errs[3] = strconv.Atoi(fields[4])
+		for _, err := range errs {
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, scanner.Err()
+}
+
+// Fetch reads the 1-based inclusive range start..end of the sequence from the FASTA file
+func (e FAIEntry) Fetch(reader io.ReaderAt, start, end int) (string, error) {
+	if start < 1 {
+		start = 1
+	}
+	if end > e.Length {
+		end = e.Length
+	}
+	if start > end || e.LineBases == 0 {
+		return "", nil
+	}
+
+	// Byte positions of the first and last base, skipping line terminators
+	position := func(base int) int64 {
+		return e.Offset + int64(base/e.LineBases)*int64(e.LineWidth) + int64(base%e.LineBases)
+	}
+	first, last := position(start-1), position(end-1)
+
+	buffer := make([]byte, last-first+1)
+	if _, err := reader.ReadAt(buffer, first); err != nil && err != io.EOF {
+		return "", err
+	}
+
+	var sequence strings.Builder
+	sequence.Grow(end - start + 1)
+	for _, b := range buffer {
+		if b != '\n' && b != '\r' {
+			sequence.WriteByte(b)
+		}
+	}
+	return sequence.String(), nil
+}
+
+// ParseRegion parses a samtools-style region such as "chr3", "chr3:100" or
+// "chr3:100-300" into a name and 1-based inclusive coordinates. An end of 0 means the
+// end of the sequence, so "chr3:100" runs from base 100 to the end as in samtools.
+func ParseRegion(region string) (name string, start, end int, ok bool) {
+	colon := strings.LastIndex(region, ":")
+	if colon < 0 {
+		return region, 1, 0, region != ""
+	}
+
+	name = region[:colon]
+	span := strings.ReplaceAll(region[colon+1:], ",", "")
+	low, high, isRange := strings.Cut(span, "-")
+
+	start, err := strconv.Atoi(low)
+	if err != nil || start < 1 || name == "" {
+		return "", 0, 0, false
+	}
+	if !isRange || high == "" {
+		return name, start, 0, true
+	}
+	end, err = strconv.Atoi(high)
+	if err != nil || end < start {
+		return "", 0, 0, false
+	}
+	return name, start, end, true
+}
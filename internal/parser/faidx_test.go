@@ -0,0 +1,144 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRegion(t *testing.T) {
+	tests := []struct {
+		region     string
+		name       string
+		start, end int
+		ok         bool
+	}{
+		{"chr3", "chr3", 1, 0, true},
+		{"chr3:100", "chr3", 100, 0, true},
+		{"chr3:100-", "chr3", 100, 0, true},
+		{"chr3:100-300", "chr3", 100, 300, true},
+		{"chr3:1,000-2,000", "chr3", 1000, 2000, true},
+		{"HLA:A:10-20", "HLA:A", 10, 20, true},
+		{"chr3:300-100", "", 0, 0, false},
+		{"chr3:0-10", "", 0, 0, false},
+		{"chr3:abc", "", 0, 0, false},
+		{":10-20", "", 0, 0, false},
+		{"", "", 1, 0, false},
+	}
+
+	for _, tt := range tests {
+		name, start, end, ok := ParseRegion(tt.region)
+		if ok != tt.ok || (ok && (name != tt.name || start != tt.start || end != tt.end)) {
+			t.Errorf("ParseRegion(%q) = %q, %d, %d, %v, want %q, %d, %d, %v",
+				tt.region, name, start, end, ok, tt.name, tt.start, tt.end, tt.ok)
+		}
+	}
+}
+
+func TestBuildFAI(t *testing.T) {
+	tests := []struct {
+		name  string
+		fasta string
+		want  []FAIEntry
+	}{
+		{
+			name:  "last short line",
+			fasta: ">chr1 description\nACGTA\nCGTAC\nGG\n>chr2\nTTTT\n",
+			want: []FAIEntry{
+				{Name: "chr1", Length: 12, Offset: 18, LineBases: 5, LineWidth: 6},
+				{Name: "chr2", Length: 4, Offset: 39, LineBases: 4, LineWidth: 5},
+			},
+		},
+		{
+			name:  "CRLF line endings",
+			fasta: ">chr1\r\nACGT\r\nAC\r\n",
+			want:  []FAIEntry{{Name: "chr1", Length: 6, Offset: 7, LineBases: 4, LineWidth: 6}},
+		},
+		{
+			name:  "no trailing newline",
+			fasta: ">chr1\nACGT\nAC",
+			want:  []FAIEntry{{Name: "chr1", Length: 6, Offset: 6, LineBases: 4, LineWidth: 5}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildFAI(strings.NewReader(tt.fasta))
+			if err != nil {
+				t.Fatalf("BuildFAI: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BuildFAI = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildFAIRejectsUnevenLines(t *testing.T) {
+	for _, fasta := range []string{
+		">chr1\nACGT\nAC\nACGT\n", // Short line before the end of the record
+		">chr1\nACGT\nACGTA\n",    // Line longer than the first
+		"ACGT\n>chr1\nACGT\n",     // Sequence before the first header
+	} {
+		if _, err := BuildFAI(strings.NewReader(fasta)); err == nil {
+			t.Errorf("BuildFAI(%q) succeeded, want an error", fasta)
+		}
+	}
+}
+
+func TestFAIEntryFetch(t *testing.T) {
+	for _, newline := range []string{"\n", "\r\n"} {
+		fasta := strings.ReplaceAll(">chr1\nACGTA\nCGTAC\nGG\n>chr2\nTTTT\n", "\n", newline)
+		entries, err := BuildFAI(strings.NewReader(fasta))
+		if err != nil {
+			t.Fatalf("BuildFAI: %v", err)
+		}
+
+		tests := []struct {
+			entry      int
+			start, end int
+			want       string
+		}{
+			{0, 1, 12, "ACGTACGTACGG"},
+			{0, 4, 7, "TACG"},
+			{0, 5, 6, "AC"},
+			{0, 11, 20, "GG"},
+			{0, 12, 12, "G"},
+			{1, 1, 4, "TTTT"},
+			{1, 5, 8, ""},
+		}
+		for _, tt := range tests {
+			got, err := entries[tt.entry].Fetch(strings.NewReader(fasta), tt.start, tt.end)
+			if err != nil {
+				t.Fatalf("Fetch: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("%q: Fetch(%s, %d, %d) = %q, want %q",
+					newline, entries[tt.entry].Name, tt.start, tt.end, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestWriteReadFAI(t *testing.T) {
+	entries := []FAIEntry{
+		{Name: "chr1", Length: 12, Offset: 6, LineBases: 5, LineWidth: 6},
+		{Name: "chrM", Length: 16569, Offset: 40, LineBases: 60, LineWidth: 61},
+	}
+
+	var index strings.Builder
+	if err := WriteFAI(&index, entries); err != nil {
+		t.Fatalf("WriteFAI: %v", err)
+	}
+	if want := "chr1\t12\t6\t5\t6\nchrM\t16569\t40\t60\t61\n"; index.String() != want {
+		t.Errorf("WriteFAI = %q, want %q", index.String(), want)
+	}
+
+	got, err := ReadFAI(strings.NewReader(index.String()))
+	if err != nil {
+		t.Fatalf("ReadFAI: %v", err)
+	}
+	if !reflect.DeepEqual(got, entries) {
+		t.Errorf("ReadFAI = %+v, want %+v", got, entries)
+	}
+}
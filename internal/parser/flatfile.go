@@ -0,0 +1,37 @@
+package parser
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// locationRangeRegex matches the ranges and single positions of a feature location,
+// skipping positions on remote entries such as "J00194.1:100..202"
+var locationRangeRegex = regexp.MustCompile(`(?:^|[^\w.:])[<>]?(\d+)(?:\.\.[<>]?(\d+))?`)
+
+// FeatureSpan is a range covered by a GenBank/EMBL feature, in 1-based inclusive coordinates
+type FeatureSpan struct {
+	Key   string
+	Start int
+	End   int
+}
+
+// ParseLocation returns the spans of a feature location such as
+// "complement(join(<1..206,300..>450))"
+func ParseLocation(key, location string) []FeatureSpan {
+	var spans []FeatureSpan
+	for _, match := range locationRangeRegex.FindAllStringSubmatch(location, -1) {
+		start, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+		end := start
+		if match[2] != "" {
+			if end, err = strconv.Atoi(match[2]); err != nil {
+				continue
+			}
+		}
+		spans = append(spans, FeatureSpan{Key: key, Start: start, End: end})
+	}
+	return spans
+}
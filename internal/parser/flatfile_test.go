@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLocation(t *testing.T) {
+	tests := []struct {
+		name     string
+		location string
+		want     []FeatureSpan
+	}{
+		{"range", "100..200", []FeatureSpan{{"CDS", 100, 200}}},
+		{"single position", "467", []FeatureSpan{{"CDS", 467, 467}}},
+		{"partial ends", "<1..>206", []FeatureSpan{{"CDS", 1, 206}}},
+		{"complement", "complement(340..565)", []FeatureSpan{{"CDS", 340, 565}}},
+		{
+			"complement of join",
+			"complement(join(<1..206,300..>450))",
+			[]FeatureSpan{{"CDS", 1, 206}, {"CDS", 300, 450}},
+		},
+		{"between bases", "123^124", []FeatureSpan{{"CDS", 123, 123}, {"CDS", 124, 124}}},
+		{
+			"remote entry skipped",
+			"join(J00194.1:100..202,1..245)",
+			[]FeatureSpan{{"CDS", 1, 245}},
+		},
+		{"empty", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseLocation("CDS", tt.location); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLocation(%q) = %v, want %v", tt.location, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package parser
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// gfaRecordTypes are the record type letters of GFA1 and GFA2
+const gfaRecordTypes = "HSLCPWEFGOU"
+
+// IsGFALine checks if a line is a tab-separated GFA record
+func IsGFALine(line string) bool {
+	return len(line) > 2 && line[1] == '\t' && strings.IndexByte(gfaRecordTypes, line[0]) >= 0
+}
+
+// GFASegmentLength returns the length of a GFA1 ("S name sequence [tags]") or GFA2
+// ("S sid length sequence [tags]") segment, using the LN tag when the sequence is '*'
+func GFASegmentLength(fields []string) (int, bool) {
+	if len(fields) < 3 || fields[0] != "S" {
+		return 0, false
+	}
+	if IsGFA2Segment(fields) {
+		length, err := strconv.Atoi(fields[2])
+		return length, err == nil
+	}
+	if fields[2] != "*" {
+		return len(fields[2]), true
+	}
+	for _, tag := range fields[3:] {
+		if value, found := strings.CutPrefix(tag, "LN:i:"); found {
+			length, err := strconv.Atoi(value)
+			return length, err == nil
+		}
+	}
+	return 0, false
+}
+
+// IsGFA2Segment reports whether a segment record has the GFA2 length column
+func IsGFA2Segment(fields []string) bool {
+	if len(fields) < 4 {
+		return false
+	}
+	_, err := strconv.Atoi(fields[2])
+	return err == nil
+}
+
+// N50 returns the length such that sequences at least this long hold half the total
+func N50(lengths []int) int {
+	sorted := make([]int, len(lengths))
+	copy(sorted, lengths)
+	sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+
+	total := 0
+	for _, length := range sorted {
+		total += length
+	}
+	sum := 0
+	for _, length := range sorted {
+		sum += length
+		if sum*2 >= total {
+			return length
+		}
+	}
+	return 0
+}
@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestN50(t *testing.T) {
+	tests := []struct {
+		name    string
+		lengths []int
+		want    int
+	}{
+		{"empty", nil, 0},
+		{"single", []int{500}, 500},
+		{"unsorted", []int{2, 10, 3, 4, 5}, 5},
+		{"half reached by two", []int{5, 5, 4, 3, 2, 1}, 5},
+		{"exactly half", []int{10, 6, 4}, 10},
+		{"equal lengths", []int{7, 7, 7, 7}, 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := N50(tt.lengths); got != tt.want {
+				t.Errorf("N50(%v) = %d, want %d", tt.lengths, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGFASegmentLength(t *testing.T) {
+	tests := []struct {
+		name   string
+		line   string
+		want   int
+		wantOK bool
+	}{
+		{"gfa1 sequence", "S\ts1\tACGTA", 5, true},
+		{"gfa1 LN tag", "S\ts1\t*\tRC:i:10\tLN:i:1200", 1200, true},
+		{"gfa1 without length", "S\ts1\t*", 0, false},
+		{"gfa2 length column", "S\ts1\t850\tACGT", 850, true},
+		{"not a segment", "L\ts1\t+\ts2\t-\t4M", 0, false},
+		{"too short", "S\ts1", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := GFASegmentLength(strings.Split(tt.line, "\t"))
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("GFASegmentLength(%q) = %d, %v, want %d, %v", tt.line, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+)
+
+// AlignmentRow is a line of an alignment block split around its residues, so that the
+// name and any trailing position can be printed as they were read
+type AlignmentRow struct {
+	Prefix     string // Sequence name and the spacing up to the residues
+	Residues   string // Aligned residues and gaps, possibly grouped by spaces
+	Suffix     string // Anything after the residues, such as a Clustal position count
+	Annotation bool   // Stockholm #=GC/#=GR annotation, excluded from column statistics
+}
+
+// IsGap reports whether an alignment character is a gap
+func IsGap(char byte) bool {
+	return char == '-' || char == '.' || char == '~'
+}
+
+// isResidue reports whether a character can appear in aligned residues
+func isResidue(char byte) bool {
+	return (char >= 'A' && char <= 'Z') || (char >= 'a' && char <= 'z') || IsGap(char) || char == '*'
+}
+
+// ParsePHYLIPHeader parses the "taxa characters" first line of a PHYLIP file,
+// returning the number of taxa
+func ParsePHYLIPHeader(line string) (int, bool) {
+	fields := strings.Fields(line)
+	if len(fields) != 2 {
+		return 0, false
+	}
+	taxa, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, false
+	}
+	if _, err := strconv.Atoi(fields[1]); err != nil {
+		return 0, false
+	}
+	return taxa, true
+}
+
+// ParseClustalRow parses a "name residues [count]" Clustal line. Header and
+// conservation lines are not rows.
+func ParseClustalRow(line string) (AlignmentRow, bool) {
+	if line == "" || line[0] == ' ' || strings.HasPrefix(line, "CLUSTAL") {
+		return AlignmentRow{}, false
+	}
+	return splitNamedRow(line, false)
+}
+
+// IsClustalConservationLine checks if a line is the "*:." conservation line under a
+// Clustal block, which is indented to the residues and may be blank if nothing is conserved
+func IsClustalConservationLine(line string) bool {
+	return line != "" && line[0] == ' ' && strings.Trim(line, " *:.") == ""
+}
+
+// ParseStockholmRow parses a "name residues" Stockholm line or a "#=GC tag residues" /
+// "#=GR name tag residues" annotation line
+func ParseStockholmRow(line string) (AlignmentRow, bool) {
+	if line == "" || line == "//" {
+		return AlignmentRow{}, false
+	}
+
+	nameFields := 1
+	switch {
+	case strings.HasPrefix(line, "#=GC "):
+		nameFields = 2
+	case strings.HasPrefix(line, "#=GR "):
+		nameFields = 3
+	case strings.HasPrefix(line, "#"):
+		return AlignmentRow{}, false
+	}
+
+	row, ok := splitRow(line, nameFields, false)
+	if nameFields > 1 {
+		// Annotations use their own alphabets, such as "<<..>>" for secondary structure
+		row.Annotation = true
+		return row, ok
+	}
+	return row, ok && onlyResidues(row.Residues, false)
+}
+
+// ParsePHYLIPRow parses a PHYLIP line. Named rows start with the taxon name, while rows
+// of later interleaved blocks contain only residues. Residues may be grouped by spaces.
+func ParsePHYLIPRow(line string, named bool) (AlignmentRow, bool) {
+	if strings.TrimSpace(line) == "" {
+		return AlignmentRow{}, false
+	}
+	if !named {
+		residues := strings.TrimLeft(line, " \t")
+		row := AlignmentRow{Prefix: line[:len(line)-len(residues)], Residues: residues}
+		return row, onlyResidues(residues, true)
+	}
+	return splitNamedRow(line, true)
+}
+
+// splitNamedRow splits a line whose first whitespace-separated field is the sequence name
+func splitNamedRow(line string, spaced bool) (AlignmentRow, bool) {
+	row, ok := splitRow(line, 1, spaced)
+	return row, ok && onlyResidues(row.Residues, spaced)
+}
+
+// splitRow splits a line after nameFields whitespace-separated name fields. Spaced
+// residues may contain spaces, otherwise the residues end at the next whitespace.
+func splitRow(line string, nameFields int, spaced bool) (AlignmentRow, bool) {
+	pos := 0
+	for i := 0; i < nameFields; i++ {
+		for pos < len(line) && (line[pos] == ' ' || line[pos] == '\t') {
+			pos++
+		}
+		for pos < len(line) && line[pos] != ' ' && line[pos] != '\t' {
+			pos++
+		}
+	}
+	start := pos
+	for start < len(line) && (line[start] == ' ' || line[start] == '\t') {
+		start++
+	}
+	if start == pos || start == len(line) {
+		return AlignmentRow{}, false // No separator or no residues
+	}
+
+	end := len(line)
+	if !spaced {
+		end = start
+		for end < len(line) && line[end] != ' ' && line[end] != '\t' {
+			end++
+		}
+	}
+	end = start + len(strings.TrimRight(line[start:end], " \t"))
+
+	row := AlignmentRow{Prefix: line[:start], Residues: line[start:end], Suffix: line[end:]}
+	if suffix := strings.TrimSpace(row.Suffix); suffix != "" {
+		if _, err := strconv.Atoi(suffix); err != nil {
+			return AlignmentRow{}, false
+		}
+	}
+	return row, true
+}
+
+// onlyResidues reports whether a string consists of residues, and spaces if allowed
+func onlyResidues(residues string, spaced bool) bool {
+	for i := 0; i < len(residues); i++ {
+		if residues[i] == ' ' && spaced {
+			continue
+		}
+		if !isResidue(residues[i]) {
+			return false
+		}
+	}
+	return residues != ""
+}
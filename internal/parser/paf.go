@@ -0,0 +1,40 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+)
+
+// pafIntegerColumns are the PAF columns holding lengths, coordinates and counts
+var pafIntegerColumns = []int{1, 2, 3, 6, 7, 8, 9, 10, 11}
+
+// IsPAFLine checks if a line has the 12 mandatory columns of a PAF record
+func IsPAFLine(line string) bool {
+	fields := strings.Split(line, "\t")
+	if len(fields) < 12 {
+		return false
+	}
+	if fields[4] != "+" && fields[4] != "-" {
+		return false
+	}
+	for _, i := range pafIntegerColumns {
+		if _, err := strconv.Atoi(fields[i]); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// MAFSequenceText returns the alignment text of a MAF "s" line and its byte offset
+// in the line, or ok=false for other lines
+func MAFSequenceText(line string) (text string, offset int, ok bool) {
+	if !strings.HasPrefix(line, "s ") {
+		return "", 0, false
+	}
+	trimmed := strings.TrimRight(line, " \t")
+	offset = strings.LastIndexAny(trimmed, " \t") + 1
+	if len(strings.Fields(trimmed)) != 7 {
+		return "", 0, false
+	}
+	return trimmed[offset:], offset, true
+}
@@ -3,6 +3,7 @@ package parser
 import (
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -15,6 +16,19 @@ const (
 	FormatFASTQ
 	FormatSAM
 	FormatVCF
+	FormatGFF
+	FormatBED
+	FormatBedGraph
+	FormatGenBank
+	FormatEMBL
+	FormatClustal
+	FormatStockholm
+	FormatPHYLIP
+	FormatAlignedFASTA
+	FormatPAF
+	FormatMAF
+	FormatPileup
+	FormatGFA
 )
 
 var (
@@ -38,6 +52,32 @@ func DetectFormatFromFilename(filename string) Format {
 		return FormatSAM
 	case ".vcf":
 		return FormatVCF
+	case ".gff", ".gff3", ".gtf", ".gff2":
+		return FormatGFF
+	case ".bed":
+		return FormatBED
+	case ".bedgraph", ".bdg":
+		return FormatBedGraph
+	case ".gb", ".gbk", ".gbff", ".genbank":
+		return FormatGenBank
+	case ".embl":
+		return FormatEMBL
+	case ".aln", ".clustal", ".clw":
+		return FormatClustal
+	case ".sto", ".stk", ".stockholm":
+		return FormatStockholm
+	case ".phy", ".phylip":
+		return FormatPHYLIP
+	case ".afa", ".afasta", ".mfa":
+		return FormatAlignedFASTA
+	case ".paf":
+		return FormatPAF
+	case ".maf":
+		return FormatMAF
+	case ".pileup", ".mpileup":
+		return FormatPileup
+	case ".gfa", ".gfa1", ".gfa2":
+		return FormatGFA
 	default:
 		return FormatUnknown
 	}
@@ -56,6 +96,141 @@ func DetectFormatFromContent(lines []string) Format {
 		}
 	}
 
+	// Check for GenBank and EMBL flat files
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "LOCUS ") {
+			return FormatGenBank
+		}
+		if strings.HasPrefix(line, "ID   ") {
+			return FormatEMBL
+		}
+		break
+	}
+
+	// Check for multiple sequence alignments
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "##maf"):
+			return FormatMAF
+		case strings.HasPrefix(line, "# STOCKHOLM"):
+			return FormatStockholm
+		case strings.HasPrefix(line, "CLUSTAL") || strings.HasPrefix(line, "MUSCLE") ||
+			strings.HasPrefix(line, "PROBCONS"):
+			return FormatClustal
+		}
+		if _, ok := ParsePHYLIPHeader(line); ok {
+			return FormatPHYLIP
+		}
+		break
+	}
+
+	// Check for GFF/GTF
+	for _, line := range lines {
+		if strings.HasPrefix(line, "##gff-version") || strings.HasPrefix(line, "#gtf-version") {
+			return FormatGFF
+		}
+	}
+	gffLines := 0
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "#") && line != "" {
+			if !IsGFFLine(line) {
+				gffLines = 0
+				break
+			}
+			gffLines++
+		}
+	}
+	if gffLines > 0 {
+		return FormatGFF
+	}
+
+	// Check for BED and bedGraph
+	for _, line := range lines {
+		if strings.HasPrefix(line, "track") && strings.Contains(line, "type=bedGraph") {
+			return FormatBedGraph
+		}
+		if strings.HasPrefix(line, "track") || strings.HasPrefix(line, "browser") {
+			return FormatBED
+		}
+	}
+	bedLines, bedGraphLines := 0, 0
+	for _, line := range lines {
+		if strings.HasPrefix(line, "#") || line == "" {
+			continue
+		}
+		if !IsBEDLine(line) {
+			bedLines = 0
+			break
+		}
+		bedLines++
+		if IsBedGraphLine(line) {
+			bedGraphLines++
+		}
+	}
+	if bedLines > 0 {
+		if bedGraphLines == bedLines {
+			return FormatBedGraph
+		}
+		return FormatBED
+	}
+
+	// Check for GFA assembly graphs
+	gfaLines, gfaSegments := 0, 0
+	for _, line := range lines {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !IsGFALine(line) {
+			gfaLines = 0
+			break
+		}
+		gfaLines++
+		if strings.HasPrefix(line, "S\t") || strings.HasPrefix(line, "H\t") {
+			gfaSegments++
+		}
+	}
+	if gfaLines > 0 && gfaSegments > 0 {
+		return FormatGFA
+	}
+
+	// Check for PAF, whose 12+ columns would otherwise look like SAM
+	pafLines := 0
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		if !IsPAFLine(line) {
+			pafLines = 0
+			break
+		}
+		pafLines++
+	}
+	if pafLines > 0 {
+		return FormatPAF
+	}
+
+	// Check for samtools mpileup
+	pileupLines := 0
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		if !IsPileupLine(line) {
+			pileupLines = 0
+			break
+		}
+		pileupLines++
+	}
+	if pileupLines > 0 {
+		return FormatPileup
+	}
+
 	// Check for SAM
 	for _, line := range lines {
 		if strings.HasPrefix(line, "@HD") || strings.HasPrefix(line, "@SQ") || strings.HasPrefix(line, "@RG") {
@@ -94,12 +269,106 @@ func DetectFormatFromContent(lines []string) Format {
 	if fastqHeaders > 0 {
 		return FormatFASTQ
 	} else if fastaHeaders > 0 {
+		// Gaps in the sequences mark an alignment
+		for _, line := range lines {
+			if !strings.HasPrefix(line, ">") && strings.Contains(line, "-") {
+				return FormatAlignedFASTA
+			}
+		}
 		return FormatFASTA
 	}
 
 	return FormatUnknown
 }
 
+// IsGFFLine checks if a line is a 9-column GFF/GTF feature line
+func IsGFFLine(line string) bool {
+	fields := strings.Split(line, "\t")
+	if len(fields) != 9 {
+		return false
+	}
+	if _, err := strconv.Atoi(fields[3]); err != nil {
+		return false
+	}
+	if _, err := strconv.Atoi(fields[4]); err != nil {
+		return false
+	}
+	switch fields[6] {
+	case "+", "-", ".", "?":
+		return true
+	default:
+		return false
+	}
+}
+
+// IsPileupLine checks if a line is a samtools mpileup record: chrom, position and
+// reference base, then depth, read bases and qualities for each sample
+func IsPileupLine(line string) bool {
+	fields := strings.Split(line, "\t")
+	if len(fields) < 6 || (len(fields)-3)%3 != 0 || len(fields[2]) != 1 {
+		return false
+	}
+	if _, err := strconv.Atoi(fields[1]); err != nil {
+		return false
+	}
+	for i := 3; i < len(fields); i += 3 {
+		if _, err := strconv.Atoi(fields[i]); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// IsBEDLine checks if a line is a tab-separated BED record with integer start and end
+func IsBEDLine(line string) bool {
+	fields := strings.Split(line, "\t")
+	if len(fields) < 3 || len(fields) > 12 {
+		return false
+	}
+	start, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return false
+	}
+	end, err := strconv.Atoi(fields[2])
+	return err == nil && start <= end
+}
+
+// BedGraphViewLimits returns the range from a "viewLimits=min:max" track line setting
+func BedGraphViewLimits(line string) (min, max float64, ok bool) {
+	for _, setting := range strings.Fields(line) {
+		value, found := strings.CutPrefix(setting, "viewLimits=")
+		if !found {
+			continue
+		}
+		return ParseRange(value)
+	}
+	return 0, 0, false
+}
+
+// ParseRange parses a "min:max" range
+func ParseRange(value string) (min, max float64, ok bool) {
+	low, high, found := strings.Cut(value, ":")
+	if !found {
+		return 0, 0, false
+	}
+	min, errMin := strconv.ParseFloat(low, 64)
+	max, errMax := strconv.ParseFloat(high, 64)
+	if errMin != nil || errMax != nil || max <= min {
+		return 0, 0, false
+	}
+	return min, max, true
+}
+
+// IsBedGraphLine checks if a line is a 4-column bedGraph record with a numeric value
+func IsBedGraphLine(line string) bool {
+	fields := strings.Split(line, "\t")
+	if len(fields) != 4 || !IsBEDLine(line) {
+		return false
+	}
+	_, err := strconv.ParseFloat(fields[3], 64)
+	return err == nil
+}
+
 // IsSequenceLine checks if a line contains a DNA/RNA/protein sequence
 func IsSequenceLine(line string) bool {
 	if len(line) == 0 {
@@ -163,3 +432,37 @@ func IsProteinSequence(sequence string) bool {
 	upper := strings.ToUpper(sequence)
 	return proteinRegex.MatchString(upper)
 }
+
+// iupacComplements maps IUPAC nucleotide codes to their complements
+var iupacComplements = map[rune]rune{
+	'A': 'T', 'T': 'A', 'U': 'A', 'G': 'C', 'C': 'G',
+	'R': 'Y', 'Y': 'R', 'S': 'S', 'W': 'W', 'K': 'M', 'M': 'K',
+	'B': 'V', 'V': 'B', 'D': 'H', 'H': 'D', 'N': 'N',
+	'a': 't', 't': 'a', 'u': 'a', 'g': 'c', 'c': 'g',
+	'r': 'y', 'y': 'r', 's': 's', 'w': 'w', 'k': 'm', 'm': 'k',
+	'b': 'v', 'v': 'b', 'd': 'h', 'h': 'd', 'n': 'n',
+}
+
+// ReverseComplement returns the reverse complement of an IUPAC nucleotide sequence.
+// Characters without a complement (gaps, unknown symbols) are kept as they are.
+func ReverseComplement(sequence string) string {
+	runes := []rune(sequence)
+	result := make([]rune, len(runes))
+	for i, char := range runes {
+		if comp, ok := iupacComplements[char]; ok {
+			char = comp
+		}
+		result[len(runes)-1-i] = char
+	}
+	return string(result)
+}
+
+// Transcribe converts a DNA sequence to RNA, replacing T with U
+func Transcribe(sequence string) string {
+	return strings.NewReplacer("T", "U", "t", "u").Replace(sequence)
+}
+
+// BackTranscribe converts an RNA sequence to DNA, replacing U with T
+func BackTranscribe(sequence string) string {
+	return strings.NewReplacer("U", "T", "u", "t").Replace(sequence)
+}
@@ -0,0 +1,126 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// QualityEncoding represents the ASCII encoding of FASTQ quality scores
+type QualityEncoding int
+
+const (
+	EncodingUnknown    QualityEncoding = iota
+	EncodingSanger                     // Phred+33, Sanger and Illumina 1.8+
+	EncodingSolexa                     // Solexa+64, Solexa and Illumina 1.0
+	EncodingIllumina13                 // Phred+64, Illumina 1.3+
+	EncodingIllumina15                 // Phred+64 with 'B' quality control indicator, Illumina 1.5+
+	EncodingIllumina18                 // Phred+33 with scores up to 41, Illumina 1.8+
+)
+
+// qualityEncodingNames maps command-line names to encodings
+var qualityEncodingNames = map[string]QualityEncoding{
+	"sanger":       EncodingSanger,
+	"solexa":       EncodingSolexa,
+	"illumina-1.3": EncodingIllumina13,
+	"illumina-1.5": EncodingIllumina15,
+	"illumina-1.8": EncodingIllumina18,
+}
+
+// ParseQualityEncoding parses an encoding name such as "sanger" or "illumina-1.5".
+// "auto" returns EncodingUnknown, meaning the encoding should be detected.
+func ParseQualityEncoding(name string) (QualityEncoding, error) {
+	name = strings.ToLower(name)
+	if name == "auto" || name == "" {
+		return EncodingUnknown, nil
+	}
+	if encoding, exists := qualityEncodingNames[name]; exists {
+		return encoding, nil
+	}
+	return EncodingUnknown, fmt.Errorf("unknown quality encoding '%s' (use auto, sanger, solexa, illumina-1.3, illumina-1.5 or illumina-1.8)", name)
+}
+
+// String returns a human-readable name for the encoding
+func (e QualityEncoding) String() string {
+	switch e {
+	case EncodingSanger:
+		return "Sanger (Phred+33)"
+	case EncodingSolexa:
+		return "Solexa (Solexa+64)"
+	case EncodingIllumina13:
+		return "Illumina 1.3+ (Phred+64)"
+	case EncodingIllumina15:
+		return "Illumina 1.5+ (Phred+64)"
+	case EncodingIllumina18:
+		return "Illumina 1.8+ (Phred+33)"
+	default:
+		return "Unknown"
+	}
+}
+
+// Offset returns the ASCII offset of the encoding
+func (e QualityEncoding) Offset() int {
+	switch e {
+	case EncodingSolexa, EncodingIllumina13, EncodingIllumina15:
+		return 64
+	default:
+		return 33
+	}
+}
+
+// Phred converts a quality character to a Phred score
+func (e QualityEncoding) Phred(char rune) int {
+	score := int(char) - e.Offset()
+	if e == EncodingSolexa {
+		// Solexa scores are log-odds and can be negative
+		return int(math.Round(10 * math.Log10(math.Pow(10, float64(score)/10)+1)))
+	}
+	return score
+}
+
+// DetectQualityEncoding guesses the encoding from the range of observed quality characters.
+// Phred+33 is assumed unless every character lies in the Phred+64 range: nothing below '@',
+// something above 'K', the highest Illumina Phred+33 score, and nothing above 'j', the
+// Phred+64 ceiling. Long reads (PacBio HiFi up to '~', ONT above Q42) are therefore read as
+// Phred+33. Solexa cannot be told apart from Phred+33 and is only used when forced.
+func DetectQualityEncoding(qualities []string) QualityEncoding {
+	minChar, maxChar := rune(math.MaxInt32), rune(0)
+	for _, quality := range qualities {
+		for _, char := range quality {
+			if char < minChar {
+				minChar = char
+			}
+			if char > maxChar {
+				maxChar = char
+			}
+		}
+	}
+
+	switch {
+	case maxChar == 0:
+		return EncodingUnknown
+	case minChar < '@' || maxChar <= 'K' || maxChar > 'j':
+		if maxChar == 'J' {
+			return EncodingIllumina18
+		}
+		return EncodingSanger
+	case minChar < 'B':
+		return EncodingIllumina13
+	default:
+		return EncodingIllumina15
+	}
+}
+
+// FASTQQualityLines returns the quality lines of the complete 4-line FASTQ records in lines
+func FASTQQualityLines(lines []string) []string {
+	var qualities []string
+	for i := 0; i+3 < len(lines); {
+		if !strings.HasPrefix(lines[i], "@") {
+			i++
+			continue
+		}
+		qualities = append(qualities, lines[i+3])
+		i += 4
+	}
+	return qualities
+}
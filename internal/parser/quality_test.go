@@ -0,0 +1,53 @@
+package parser
+
+import "testing"
+
+func TestDetectQualityEncoding(t *testing.T) {
+	tests := []struct {
+		name      string
+		qualities []string
+		want      QualityEncoding
+	}{
+		{"empty", nil, EncodingUnknown},
+		{"sanger with low scores", []string{"!#+5?I"}, EncodingSanger},
+		{"illumina 1.8 up to J", []string{"#AAFFJJ"}, EncodingIllumina18},
+		{"high-quality phred+33 only", []string{"IIIIF"}, EncodingSanger},
+		{"high-quality phred+33 up to K", []string{"FFKKK"}, EncodingSanger},
+		{"pacbio hifi", []string{"@@@~~~"}, EncodingSanger},
+		{"ont above Q42", []string{"<<<PPPZZ"}, EncodingSanger},
+		{"solexa range read as phred+33", []string{";;@hhh"}, EncodingSanger},
+		{"illumina 1.3", []string{"@@Thhh"}, EncodingIllumina13},
+		{"illumina 1.5", []string{"BBBThhh"}, EncodingIllumina15},
+		{"phred+64 without evidence above K", []string{"BBBFFF"}, EncodingSanger},
+		{"across lines", []string{"hhhh", "!!!!"}, EncodingSanger},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectQualityEncoding(tt.qualities); got != tt.want {
+				t.Errorf("DetectQualityEncoding(%q) = %v, want %v", tt.qualities, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQualityEncodingPhred(t *testing.T) {
+	tests := []struct {
+		encoding QualityEncoding
+		char     rune
+		want     int
+	}{
+		{EncodingSanger, 'I', 40},
+		{EncodingSanger, '!', 0},
+		{EncodingIllumina18, 'J', 41},
+		{EncodingIllumina13, 'h', 40},
+		{EncodingSolexa, ';', 1},
+		{EncodingSolexa, 'h', 40},
+	}
+
+	for _, tt := range tests {
+		if got := tt.encoding.Phred(tt.char); got != tt.want {
+			t.Errorf("%v.Phred(%q) = %d, want %d", tt.encoding, tt.char, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+)
+
+// SAM FLAG bits
+const (
+	SAMFlagPaired        = 0x1
+	SAMFlagProperPair    = 0x2
+	SAMFlagUnmapped      = 0x4
+	SAMFlagMateUnmapped  = 0x8
+	SAMFlagReverse       = 0x10
+	SAMFlagMateReverse   = 0x20
+	SAMFlagRead1         = 0x40
+	SAMFlagRead2         = 0x80
+	SAMFlagSecondary     = 0x100
+	SAMFlagQCFail        = 0x200
+	SAMFlagDuplicate     = 0x400
+	SAMFlagSupplementary = 0x800
+)
+
+// samFlagNames lists the short names of the SAM FLAG bits in bit order
+var samFlagNames = []struct {
+	bit  int
+	name string
+}{
+	{SAMFlagPaired, "paired"},
+	{SAMFlagProperPair, "proper"},
+	{SAMFlagUnmapped, "unmapped"},
+	{SAMFlagMateUnmapped, "mate-unmapped"},
+	{SAMFlagReverse, "reverse"},
+	{SAMFlagMateReverse, "mate-reverse"},
+	{SAMFlagRead1, "read1"},
+	{SAMFlagRead2, "read2"},
+	{SAMFlagSecondary, "secondary"},
+	{SAMFlagQCFail, "qcfail"},
+	{SAMFlagDuplicate, "duplicate"},
+	{SAMFlagSupplementary, "supplementary"},
+}
+
+// DecodeSAMFlag returns the short names of the bits set in a SAM FLAG
+func DecodeSAMFlag(flag int) []string {
+	var names []string
+	for _, f := range samFlagNames {
+		if flag&f.bit != 0 {
+			names = append(names, f.name)
+		}
+	}
+	return names
+}
+
+// SAMReference is an @SQ reference sequence
+type SAMReference struct {
+	Name   string
+	Length int
+}
+
+// SAMProgram is an @PG program record
+type SAMProgram struct {
+	ID          string
+	Name        string // PN
+	Version     string // VN
+	CommandLine string // CL
+	Previous    string // PP, the ID of the previous program in the chain
+}
+
+// SAMHeader collects the @SQ and @PG records of a SAM header
+type SAMHeader struct {
+	References []SAMReference
+	Programs   []SAMProgram
+}
+
+// AddLine records an @SQ or @PG header line, reporting whether the line was one
+func (h *SAMHeader) AddLine(line string) bool {
+	fields := strings.Split(line, "\t")
+	tags := make(map[string]string)
+	for _, field := range fields[1:] {
+		if key, value, found := strings.Cut(field, ":"); found {
+			tags[key] = value
+		}
+	}
+
+	switch fields[0] {
+	case "@SQ":
+		length, _ := strconv.Atoi(tags["LN"])
+		h.References = append(h.References, SAMReference{Name: tags["SN"], Length: length})
+	case "@PG":
+		h.Programs = append(h.Programs, SAMProgram{
+			ID:          tags["ID"],
+			Name:        tags["PN"],
+			Version:     tags["VN"],
+			CommandLine: tags["CL"],
+			Previous:    tags["PP"],
+		})
+	default:
+		return false
+	}
+	return true
+}
+
+// ProgramChain orders the @PG records from the first program run to the last by
+// following their PP links. Programs outside the chain keep their header order.
+func (h *SAMHeader) ProgramChain() []SAMProgram {
+	next := make(map[string][]int)
+	ids := make(map[string]bool)
+	for _, pg := range h.Programs {
+		ids[pg.ID] = true
+	}
+	var roots []int
+	for i, pg := range h.Programs {
+		if pg.Previous == "" || !ids[pg.Previous] {
+			roots = append(roots, i)
+		} else {
+			next[pg.Previous] = append(next[pg.Previous], i)
+		}
+	}
+
+	var chain []SAMProgram
+	visited := make(map[int]bool)
+	var visit func(i int)
+	visit = func(i int) {
+		if visited[i] {
+			return
+		}
+		visited[i] = true
+		chain = append(chain, h.Programs[i])
+		for _, child := range next[h.Programs[i].ID] {
+			visit(child)
+		}
+	}
+	for _, root := range roots {
+		visit(root)
+	}
+	// Programs in a PP cycle have no root
+	for i := range h.Programs {
+		visit(i)
+	}
+	return chain
+}
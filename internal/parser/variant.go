@@ -0,0 +1,45 @@
+package parser
+
+import "strings"
+
+// VariantType represents the class of a VCF alternative allele relative to its reference
+type VariantType int
+
+const (
+	VariantUnknown      VariantType = iota
+	VariantTransition               // SNV between purines or between pyrimidines
+	VariantTransversion             // SNV between a purine and a pyrimidine
+	VariantMNV                      // Multi-nucleotide substitution of equal length
+	VariantInsertion                // ALT longer than REF
+	VariantDeletion                 // ALT shorter than REF
+	VariantSymbolic                 // Symbolic allele, breakend or spanning deletion
+)
+
+// ClassifyVariant classifies an alternative allele against the reference allele
+func ClassifyVariant(ref, alt string) VariantType {
+	if alt == "" || alt == "." || ref == "" {
+		return VariantUnknown
+	}
+	if strings.HasPrefix(alt, "<") || strings.ContainsAny(alt, "[]*") {
+		return VariantSymbolic
+	}
+
+	ref, alt = strings.ToUpper(ref), strings.ToUpper(alt)
+	switch {
+	case len(alt) > len(ref):
+		return VariantInsertion
+	case len(alt) < len(ref):
+		return VariantDeletion
+	case len(ref) > 1:
+		return VariantMNV
+	case isPurine(ref[0]) == isPurine(alt[0]):
+		return VariantTransition
+	default:
+		return VariantTransversion
+	}
+}
+
+// isPurine reports whether a nucleotide is a purine (A or G)
+func isPurine(base byte) bool {
+	return base == 'A' || base == 'G'
+}
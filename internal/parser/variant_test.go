@@ -0,0 +1,33 @@
+package parser
+
+import "testing"
+
+func TestClassifyVariant(t *testing.T) {
+	tests := []struct {
+		name     string
+		ref, alt string
+		want     VariantType
+	}{
+		{"transition A>G", "A", "G", VariantTransition},
+		{"transition C>T", "C", "T", VariantTransition},
+		{"transversion A>C", "A", "C", VariantTransversion},
+		{"transversion G>T", "G", "T", VariantTransversion},
+		{"lowercase", "a", "g", VariantTransition},
+		{"mnv", "AC", "GT", VariantMNV},
+		{"insertion", "A", "ACGT", VariantInsertion},
+		{"deletion", "ACGT", "A", VariantDeletion},
+		{"symbolic", "A", "<DEL>", VariantSymbolic},
+		{"breakend", "G", "G]17:198982]", VariantSymbolic},
+		{"spanning deletion", "A", "*", VariantSymbolic},
+		{"missing alt", "A", ".", VariantUnknown},
+		{"empty ref", "", "A", VariantUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyVariant(tt.ref, tt.alt); got != tt.want {
+				t.Errorf("ClassifyVariant(%q, %q) = %d, want %d", tt.ref, tt.alt, got, tt.want)
+			}
+		})
+	}
+}
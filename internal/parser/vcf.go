@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+)
+
+// VCFField is an ##INFO or ##FORMAT definition from a VCF header
+type VCFField struct {
+	ID          string
+	Number      string // Integer, "A", "R", "G" or "."
+	Type        string // Integer, Float, Flag, Character or String
+	Description string
+}
+
+// VCFHeader holds the INFO and FORMAT definitions declared in a VCF header
+type VCFHeader struct {
+	Info   map[string]VCFField
+	Format map[string]VCFField
+}
+
+// NewVCFHeader creates an empty VCF header
+func NewVCFHeader() *VCFHeader {
+	return &VCFHeader{
+		Info:   make(map[string]VCFField),
+		Format: make(map[string]VCFField),
+	}
+}
+
+// ParseLine records an ##INFO or ##FORMAT definition, reporting whether the line was one
+func (h *VCFHeader) ParseLine(line string) bool {
+	var target map[string]VCFField
+	var body string
+	switch {
+	case strings.HasPrefix(line, "##INFO=<"):
+		target, body = h.Info, strings.TrimPrefix(line, "##INFO=<")
+	case strings.HasPrefix(line, "##FORMAT=<"):
+		target, body = h.Format, strings.TrimPrefix(line, "##FORMAT=<")
+	default:
+		return false
+	}
+
+	attrs := parseHeaderAttributes(strings.TrimSuffix(body, ">"))
+	field := VCFField{
+		ID:          attrs["ID"],
+		Number:      attrs["Number"],
+		Type:        attrs["Type"],
+		Description: attrs["Description"],
+	}
+	if field.ID == "" {
+		return false
+	}
+	target[field.ID] = field
+	return true
+}
+
+// parseHeaderAttributes splits key=value pairs separated by commas, honoring quoted values
+func parseHeaderAttributes(body string) map[string]string {
+	attrs := make(map[string]string)
+	inQuotes := false
+	start := 0
+	for i := 0; i <= len(body); i++ {
+		if i < len(body) {
+			if body[i] == '"' {
+				inQuotes = !inQuotes
+			}
+			if body[i] != ',' || inQuotes {
+				continue
+			}
+		}
+		if key, value, found := strings.Cut(body[start:i], "="); found {
+			attrs[key] = strings.Trim(value, `"`)
+		}
+		start = i + 1
+	}
+	return attrs
+}
+
+// ValidValue reports whether an INFO or FORMAT value matches the field's declared
+// Type and Number. altCount is the number of ALT alleles of the record.
+func (f VCFField) ValidValue(value string, altCount int) bool {
+	if f.Type == "Flag" {
+		return value == ""
+	}
+
+	values := strings.Split(value, ",")
+	switch f.Number {
+	case "A":
+		if len(values) != altCount {
+			return false
+		}
+	case "R":
+		if len(values) != altCount+1 {
+			return false
+		}
+	case "G", ".", "":
+	default:
+		if n, err := strconv.Atoi(f.Number); err == nil && len(values) != n {
+			return false
+		}
+	}
+
+	for _, v := range values {
+		if v == "." {
+			continue // Missing value
+		}
+		switch f.Type {
+		case "Integer":
+			if _, err := strconv.Atoi(v); err != nil {
+				return false
+			}
+		case "Float":
+			if _, err := strconv.ParseFloat(v, 64); err != nil {
+				return false
+			}
+		case "Character":
+			if len(v) != 1 {
+				return false
+			}
+		}
+	}
+	return true
+}